@@ -1,13 +1,18 @@
 package buffer
 
 import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/style"
 	"github.com/bekreth/screen_reader_terminal/utils"
 )
 
 type BufferValues struct {
-	Prefix   string
-	Value    string
-	Position int
+	Prefix             string
+	ContinuationPrefix string
+	Value              string
+	Position           int
 }
 
 type Buffer struct {
@@ -17,6 +22,35 @@ type Buffer struct {
 	currentValue     string
 	previousPosition int
 	previousValue    string
+
+	// Styling of the prefix, with prefix holding its text
+	prefixSpans []style.Span
+	// Drawn at the right edge of the first row while the input leaves room for it
+	rightPrompt []style.Span
+
+	// Drawn at the start of every line after the first, like PS2 in bash
+	continuationPrefix         string
+	previousContinuationPrefix string
+
+	// Masked buffers hold placeholders, with the real text as runes so it can be zeroed
+	masked bool
+	mask   rune
+	secret []rune
+
+	// Column kept through vertical moves while the cursor stays where they left it
+	goalColumn   int
+	goalPosition int
+	hasGoal      bool
+
+	// Overwrite mode keeps what each typed character replaced so backspace can restore it
+	overwrite         bool
+	overwritten       []string
+	overwritePosition int
+
+	// Constraints on what can be added, with 0 and nil meaning no constraint
+	maxLength int
+	filter    func(rune) bool
+	transform func(rune) rune
 }
 
 func NewBuffer() Buffer {
@@ -43,10 +77,60 @@ func (buffer *Buffer) GetPrefix() string {
 
 func (buffer *Buffer) SetPrefix(input string) *Buffer {
 	buffer.prefix = input
+	buffer.prefixSpans = nil
+	return buffer
+}
+
+// Sets a prefix drawn in styled spans, with only their text taking up width
+func (buffer *Buffer) SetStyledPrefix(spans ...style.Span) *Buffer {
+	buffer.prefix = style.Text(spans...)
+	buffer.prefixSpans = spans
+	return buffer
+}
+
+func (buffer *Buffer) GetStyledPrefix() []style.Span {
+	if buffer.prefixSpans == nil {
+		return []style.Span{style.Plain(buffer.prefix)}
+	}
+	return buffer.prefixSpans
+}
+
+// Sets text to draw at the right edge of the first row, such as the time or a git
+// branch, which is hidden while the input is long enough to reach it
+func (buffer *Buffer) SetRightPrompt(spans ...style.Span) *Buffer {
+	buffer.rightPrompt = spans
+	return buffer
+}
+
+func (buffer Buffer) GetRightPrompt() []style.Span {
+	return buffer.rightPrompt
+}
+
+// The style of each byte of the prefix, or nil if it is unstyled
+func (buffer Buffer) PrefixStyles() []style.Style {
+	return style.Styles(buffer.prefixSpans...)
+}
+
+func (buffer *Buffer) GetContinuationPrefix() string {
+	return buffer.continuationPrefix
+}
+
+func (buffer *Buffer) SetContinuationPrefix(input string) *Buffer {
+	buffer.continuationPrefix = input
 	return buffer
 }
 
+// Replaces the value, leaving the cursor at the end.  A value that breaks the
+// constraints is ignored, leaving the buffer unchanged.
 func (buffer *Buffer) SetString(input string) *Buffer {
+	input, accepted := buffer.constrainReplacing(input, buffer.length())
+	if !accepted {
+		return buffer
+	}
+	if buffer.masked {
+		buffer.replaceRange(0, len(buffer.currentValue), input)
+		return buffer
+	}
 	buffer.currentValue = input
 	buffer.currentPosition = len(input)
 	return buffer
@@ -54,6 +138,7 @@ func (buffer *Buffer) SetString(input string) *Buffer {
 
 func (buffer *Buffer) SetCurrentValues(input BufferValues) *Buffer {
 	buffer.prefix = input.Prefix
+	buffer.continuationPrefix = input.ContinuationPrefix
 	buffer.currentValue = input.Value
 	buffer.currentPosition = input.Position
 	return buffer
@@ -61,35 +146,98 @@ func (buffer *Buffer) SetCurrentValues(input BufferValues) *Buffer {
 
 func (buffer *Buffer) SetPreviousValues(input BufferValues) *Buffer {
 	buffer.previousPrefix = input.Prefix
+	buffer.previousContinuationPrefix = input.ContinuationPrefix
 	buffer.previousValue = input.Value
 	buffer.previousPosition = input.Position
 	return buffer
 }
 
-// Adds a string to the cursor position
-func (buffer *Buffer) AddString(input string) {
+// Adds a string to the cursor position, or over the text after it in overwrite mode.
+// Returns false, leaving the buffer unchanged, if any of it breaks the constraints.
+func (buffer *Buffer) AddString(input string) bool {
+	input, accepted := buffer.constrain(input)
+	if !accepted {
+		return false
+	}
+	if buffer.overwrite {
+		for _, character := range input {
+			buffer.overwriteCharacter(character)
+		}
+		return true
+	}
+	if buffer.masked {
+		buffer.replaceRange(buffer.currentPosition, buffer.currentPosition, input)
+		return true
+	}
 	buffer.currentValue = buffer.currentValue[0:buffer.currentPosition] +
 		input +
 		buffer.currentValue[buffer.currentPosition:]
 	buffer.currentPosition += len(input)
+	return true
 }
 
-// Adds a character to the current cursor position, advancing the cursor by 1
-func (buffer *Buffer) AddCharacter(character rune) {
+// Adds a character to the current cursor position, advancing the cursor past it.
+// Returns false, leaving the buffer unchanged, if the character breaks the constraints.
+func (buffer *Buffer) AddCharacter(character rune) bool {
+	input, accepted := buffer.constrain(string(character))
+	if !accepted {
+		return false
+	}
+	character, _ = utf8.DecodeRuneInString(input)
+	if buffer.overwrite {
+		buffer.overwriteCharacter(character)
+		return true
+	}
+	if buffer.masked {
+		buffer.replaceRange(buffer.currentPosition, buffer.currentPosition, string(character))
+		return true
+	}
 	buffer.currentValue = buffer.currentValue[0:buffer.currentPosition] +
 		string(character) +
 		buffer.currentValue[buffer.currentPosition:]
-	buffer.currentPosition += 1
+	buffer.currentPosition += utf8.RuneLen(character)
+	return true
 }
 
 // Removes the character before the current cursor position if a character exists and
-// retreats the cursor by 1
+// retreats the cursor past it.  In overwrite mode the character it replaced is put back.
 func (buffer *Buffer) RemoveCharacter() {
-	if buffer.currentPosition != 0 {
-		buffer.currentPosition -= 1
+	if buffer.overwrite && buffer.restoreOverwritten() {
+		return
+	}
+	if buffer.currentPosition != 0 && buffer.masked {
+		buffer.replaceRange(buffer.currentPosition-1, buffer.currentPosition, "")
+	} else if buffer.currentPosition != 0 {
+		_, size := utf8.DecodeLastRuneInString(buffer.currentValue[:buffer.currentPosition])
+		buffer.currentPosition -= size
 		buffer.currentValue = buffer.currentValue[0:buffer.currentPosition] +
-			buffer.currentValue[buffer.currentPosition+1:]
+			buffer.currentValue[buffer.currentPosition+size:]
+	}
+}
+
+// Replaces the text from start up to the cursor with input.  Returns false, leaving the
+// buffer unchanged, if input breaks the constraints.
+func (buffer *Buffer) ReplaceToCursor(start int, input string) bool {
+	replaced := utf8.RuneCountInString(buffer.currentValue[start:buffer.currentPosition])
+	input, accepted := buffer.constrainReplacing(input, replaced)
+	if !accepted {
+		return false
+	}
+	buffer.replaceRange(start, buffer.currentPosition, input)
+	return true
+}
+
+// Replaces the text between start and end with input, returning the replaced text
+func (buffer *Buffer) replaceRange(start int, end int, input string) string {
+	if buffer.masked {
+		return buffer.replaceSecret(start, end, input)
 	}
+	removed := buffer.currentValue[start:end]
+	buffer.currentValue = buffer.currentValue[0:start] +
+		input +
+		buffer.currentValue[end:]
+	buffer.currentPosition = start + len(input)
+	return removed
 }
 
 func (buffer *Buffer) SetCursor(position int) *Buffer {
@@ -152,18 +300,46 @@ func (buffer Buffer) OutputWithoutPrefix() (string, int) {
 	return buffer.currentValue, buffer.currentPosition
 }
 
+// Joins the prefixes onto the value, moving the position along with any prefix text
+// added before it
+func withPrefixes(
+	prefix string,
+	continuationPrefix string,
+	value string,
+	position int,
+) (string, int) {
+	if continuationPrefix == "" {
+		return prefix + value, position + len(prefix)
+	}
+	newLinesBefore := strings.Count(value[:utils.IntMin(position, len(value))], "\n")
+	return prefix + strings.ReplaceAll(value, "\n", "\n"+continuationPrefix),
+		position + len(prefix) + newLinesBefore*len(continuationPrefix)
+}
+
 func (buffer Buffer) Output() (string, int) {
-	return buffer.prefix + buffer.currentValue,
-		buffer.currentPosition + len(buffer.prefix)
+	value, position := buffer.currentValue, buffer.currentPosition
+	if buffer.masked {
+		value, position = buffer.maskValue(value, position)
+	}
+	return withPrefixes(buffer.prefix, buffer.continuationPrefix, value, position)
 }
 
 func (buffer Buffer) PreviousOutput() (string, int) {
-	return buffer.previousPrefix + buffer.previousValue,
-		buffer.previousPosition + len(buffer.previousPrefix)
+	value, position := buffer.previousValue, buffer.previousPosition
+	if buffer.masked {
+		value, position = buffer.maskValue(value, position)
+	}
+	return withPrefixes(
+		buffer.previousPrefix,
+		buffer.previousContinuationPrefix,
+		value,
+		position,
+	)
 }
 
 func (buffer *Buffer) UpdatePrevious() {
 	buffer.previousPrefix = buffer.prefix
+	buffer.previousContinuationPrefix = buffer.continuationPrefix
 	buffer.previousValue = buffer.currentValue
 	buffer.previousPosition = buffer.currentPosition
 }
@@ -183,10 +359,14 @@ func (buffer *Buffer) ClearPrevious() {
 	buffer.previousPosition = 0
 
 	buffer.previousPrefix = ""
+	buffer.previousContinuationPrefix = ""
 }
 
 func (buffer *Buffer) Clear() {
+	zeroRunes(buffer.secret)
+	buffer.secret = buffer.secret[:0]
 	buffer.currentValue = ""
 	buffer.currentPosition = 0
+	buffer.overwritten = nil
 	buffer.ClearPrevious()
 }
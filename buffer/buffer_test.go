@@ -234,3 +234,72 @@ func TestRetreatCursorByWord(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputWithContinuationPrefix(t *testing.T) {
+	trials := []struct {
+		description       string
+		startingBuffer    Buffer
+		expectedOutput    string
+		expectedPosition  int
+		expectedRawOutput string
+	}{
+		{
+			description: "Single line has no continuation",
+			startingBuffer: Buffer{
+				prefix:             "> ",
+				continuationPrefix: ". ",
+				currentValue:       "hello",
+				currentPosition:    5,
+			},
+			expectedOutput:    "> hello",
+			expectedPosition:  7,
+			expectedRawOutput: "hello",
+		},
+		{
+			description: "Cursor on first of several lines",
+			startingBuffer: Buffer{
+				prefix:             "> ",
+				continuationPrefix: ". ",
+				currentValue:       "hello\nworld\nagain",
+				currentPosition:    2,
+			},
+			expectedOutput:    "> hello\n. world\n. again",
+			expectedPosition:  4,
+			expectedRawOutput: "hello\nworld\nagain",
+		},
+		{
+			description: "Cursor on last of several lines",
+			startingBuffer: Buffer{
+				prefix:             "> ",
+				continuationPrefix: ". ",
+				currentValue:       "hello\nworld\nagain",
+				currentPosition:    13,
+			},
+			expectedOutput:    "> hello\n. world\n. again",
+			expectedPosition:  19,
+			expectedRawOutput: "hello\nworld\nagain",
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			actualOutput, actualPosition := trial.startingBuffer.Output()
+			actualRawOutput, _ := trial.startingBuffer.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedOutput, actualOutput)
+			assert.Equal(tt, trial.expectedPosition, actualPosition)
+			assert.Equal(tt, trial.expectedRawOutput, actualRawOutput)
+		})
+	}
+}
+
+func TestMultibyteCharacters(t *testing.T) {
+	buffer := NewBufferWithString("a")
+	buffer.AddCharacter('é')
+	buffer.AddCharacter('日')
+	assert.Equal(t, "aé日", buffer.currentValue)
+	assert.Equal(t, 6, buffer.currentPosition)
+
+	buffer.RemoveCharacter()
+	assert.Equal(t, "aé", buffer.currentValue)
+	assert.Equal(t, 3, buffer.currentPosition)
+}
@@ -0,0 +1,99 @@
+package buffer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits the buffer to length characters, or removes the limit if length is 0
+func (buffer *Buffer) SetMaxLength(length int) *Buffer {
+	buffer.maxLength = length
+	return buffer
+}
+
+// Only accepts the characters in allowed, or any character if allowed is empty
+func (buffer *Buffer) SetAllowed(allowed string) *Buffer {
+	if allowed == "" {
+		buffer.filter = nil
+		return buffer
+	}
+	buffer.filter = func(character rune) bool {
+		return strings.ContainsRune(allowed, character)
+	}
+	return buffer
+}
+
+// Only accepts characters for which filter returns true, or any character if filter is
+// nil.  The filter sees characters after they have been transformed.
+func (buffer *Buffer) SetFilter(filter func(rune) bool) *Buffer {
+	buffer.filter = filter
+	return buffer
+}
+
+// Changes each character as it is added, such as unicode.ToUpper for hex keys
+func (buffer *Buffer) SetTransform(transform func(rune) rune) *Buffer {
+	buffer.transform = transform
+	return buffer
+}
+
+// The input as it would be added, and whether every character of it is accepted
+// without taking the buffer past its maximum length
+func (buffer Buffer) constrain(input string) (string, bool) {
+	replaced := 0
+	if buffer.overwrite {
+		replaced = buffer.overwrittenCount(buffer.transformed(input))
+	}
+	return buffer.constrainReplacing(input, replaced)
+}
+
+// The input as it would be added in place of replaced characters, and whether it is
+// accepted
+func (buffer Buffer) constrainReplacing(input string, replaced int) (string, bool) {
+	input = buffer.transformed(input)
+	if buffer.filter != nil {
+		for _, character := range input {
+			if !buffer.filter(character) {
+				return input, false
+			}
+		}
+	}
+	if buffer.maxLength == 0 {
+		return input, true
+	}
+	added := utf8.RuneCountInString(input) - replaced
+	return input, buffer.length()+added <= buffer.maxLength
+}
+
+func (buffer Buffer) transformed(input string) string {
+	if buffer.transform == nil {
+		return input
+	}
+	return strings.Map(buffer.transform, input)
+}
+
+// The number of characters in the buffer
+func (buffer Buffer) length() int {
+	if buffer.masked {
+		return len(buffer.secret)
+	}
+	return utf8.RuneCountInString(buffer.currentValue)
+}
+
+// The number of characters input would replace in overwrite mode, which never runs on
+// past the end of the line
+func (buffer Buffer) overwrittenCount(input string) int {
+	rest := buffer.currentValue[buffer.currentPosition:]
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	available := len(rest)
+	if !buffer.masked {
+		available = utf8.RuneCountInString(rest)
+	}
+	// Newlines are inserted, leaving the rest of the line to be overwritten after them
+	typed := utf8.RuneCountInString(input) - strings.Count(input, "\n")
+	if typed < available {
+		return typed
+	}
+	return available
+}
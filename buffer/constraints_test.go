@@ -0,0 +1,231 @@
+package buffer
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraints(t *testing.T) {
+	trials := []struct {
+		description      string
+		initial          string
+		constrain        func(*Buffer)
+		edit             func(*Buffer) bool
+		expectedAccepted bool
+		expectedValue    string
+	}{
+		{
+			description:      "Character within the maximum length",
+			initial:          "808",
+			constrain:        func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit:             func(buffer *Buffer) bool { return buffer.AddCharacter('0') },
+			expectedAccepted: true,
+			expectedValue:    "8080",
+		},
+		{
+			description:      "Character past the maximum length",
+			initial:          "65535",
+			constrain:        func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit:             func(buffer *Buffer) bool { return buffer.AddCharacter('1') },
+			expectedAccepted: false,
+			expectedValue:    "65535",
+		},
+		{
+			description:      "String that would pass the maximum length is rejected whole",
+			initial:          "ab",
+			constrain:        func(buffer *Buffer) { buffer.SetMaxLength(4) },
+			edit:             func(buffer *Buffer) bool { return buffer.AddString("cde") },
+			expectedAccepted: false,
+			expectedValue:    "ab",
+		},
+		{
+			description: "Overwriting does not add to the length",
+			initial:     "abcd",
+			constrain: func(buffer *Buffer) {
+				buffer.SetMaxLength(4).SetCursor(1).SetOverwrite(true)
+			},
+			edit:             func(buffer *Buffer) bool { return buffer.AddString("xy") },
+			expectedAccepted: true,
+			expectedValue:    "axyd",
+		},
+		{
+			description:      "Character outside the allowed set",
+			initial:          "12",
+			constrain:        func(buffer *Buffer) { buffer.SetAllowed("0123456789") },
+			edit:             func(buffer *Buffer) bool { return buffer.AddCharacter('a') },
+			expectedAccepted: false,
+			expectedValue:    "12",
+		},
+		{
+			description:      "String with a character outside the allowed set",
+			initial:          "",
+			constrain:        func(buffer *Buffer) { buffer.SetAllowed("0123456789") },
+			edit:             func(buffer *Buffer) bool { return buffer.AddString("1a2") },
+			expectedAccepted: false,
+			expectedValue:    "",
+		},
+		{
+			description: "Filter function",
+			initial:     "user",
+			constrain: func(buffer *Buffer) {
+				buffer.SetFilter(func(character rune) bool {
+					return unicode.IsLower(character) || character == '_'
+				})
+			},
+			edit:             func(buffer *Buffer) bool { return buffer.AddString("_name") },
+			expectedAccepted: true,
+			expectedValue:    "user_name",
+		},
+		{
+			description: "Transform is applied before the filter",
+			initial:     "",
+			constrain: func(buffer *Buffer) {
+				buffer.SetTransform(unicode.ToUpper).SetAllowed("0123456789ABCDEF")
+			},
+			edit:             func(buffer *Buffer) bool { return buffer.AddString("c0ffee") },
+			expectedAccepted: true,
+			expectedValue:    "C0FFEE",
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buffer := NewBufferWithString(trial.initial)
+			trial.constrain(&buffer)
+			accepted := trial.edit(&buffer)
+			value, _ := buffer.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedAccepted, accepted)
+			assert.Equal(tt, trial.expectedValue, value)
+		})
+	}
+}
+
+func TestMaxLengthOfMaskedBuffer(t *testing.T) {
+	buffer := NewBuffer()
+	buffer.SetMask('*').SetMaxLength(3)
+	assert.True(t, buffer.AddString("päs"))
+	assert.False(t, buffer.AddCharacter('s'))
+	assert.Equal(t, "päs", string(buffer.Secret()))
+}
+
+func TestReplacingEditsFollowConstraints(t *testing.T) {
+	trials := []struct {
+		description   string
+		initial       string
+		constrain     func(*Buffer)
+		edit          func(*Buffer, *KillRing)
+		expectedValue string
+	}{
+		{
+			description: "Value within the maximum length",
+			initial:     "65535",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.SetString("8080")
+			},
+			expectedValue: "8080",
+		},
+		{
+			description: "Value past the maximum length",
+			initial:     "8080",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.SetString("123456")
+			},
+			expectedValue: "8080",
+		},
+		{
+			description: "Value outside the allowed set",
+			initial:     "12",
+			constrain:   func(buffer *Buffer) { buffer.SetAllowed("0123456789") },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.SetString("1a")
+			},
+			expectedValue: "12",
+		},
+		{
+			description: "Value transformed",
+			initial:     "",
+			constrain:   func(buffer *Buffer) { buffer.SetTransform(unicode.ToUpper) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.SetString("ff")
+			},
+			expectedValue: "FF",
+		},
+		{
+			description: "Replacement past the maximum length",
+			initial:     "ab",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(4) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.ReplaceToCursor(0, "abcde")
+			},
+			expectedValue: "ab",
+		},
+		{
+			description: "Replacement counting the replaced text",
+			initial:     "abcd",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(4) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				buffer.ReplaceToCursor(2, "xy")
+			},
+			expectedValue: "abxy",
+		},
+		{
+			description: "Yank past the maximum length",
+			initial:     "abc",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				ring.Push("xyz")
+				buffer.Yank(ring)
+			},
+			expectedValue: "abc",
+		},
+		{
+			description: "Yank transformed",
+			initial:     "",
+			constrain:   func(buffer *Buffer) { buffer.SetTransform(unicode.ToUpper) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				ring.Push("c0ffee")
+				buffer.Yank(ring)
+			},
+			expectedValue: "C0FFEE",
+		},
+		{
+			description: "Yank pop outside the allowed set",
+			initial:     "",
+			constrain:   func(buffer *Buffer) { buffer.SetAllowed("0123456789") },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				ring.Push("abc")
+				ring.Push("123")
+				buffer.Yank(ring)
+				buffer.YankPop(ring)
+			},
+			expectedValue: "123",
+		},
+		{
+			description: "Yank pop replacing the yanked text",
+			initial:     "ab",
+			constrain:   func(buffer *Buffer) { buffer.SetMaxLength(5) },
+			edit: func(buffer *Buffer, ring *KillRing) {
+				ring.Push("xyz")
+				ring.Push("1")
+				buffer.Yank(ring)
+				buffer.YankPop(ring)
+			},
+			expectedValue: "abxyz",
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buffer := NewBufferWithString(trial.initial)
+			ring := NewKillRing()
+			trial.constrain(&buffer)
+			trial.edit(&buffer, &ring)
+			value, _ := buffer.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedValue, value)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package buffer
+
+import "unicode/utf8"
+
+func isWordSeparator(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\n'
+}
+
+// Index of the end of the word at or after the cursor
+func (buffer Buffer) nextWordEnd() int {
+	end := buffer.currentPosition
+	for end < len(buffer.currentValue) && isWordSeparator(buffer.currentValue[end]) {
+		end += 1
+	}
+	for end < len(buffer.currentValue) && !isWordSeparator(buffer.currentValue[end]) {
+		end += 1
+	}
+	return end
+}
+
+// Index of the start of the word at or before the cursor
+func (buffer Buffer) previousWordStart() int {
+	start := buffer.currentPosition
+	for start > 0 && isWordSeparator(buffer.currentValue[start-1]) {
+		start -= 1
+	}
+	for start > 0 && !isWordSeparator(buffer.currentValue[start-1]) {
+		start -= 1
+	}
+	return start
+}
+
+func (buffer *Buffer) kill(start int, end int, ring *KillRing) string {
+	if start == end {
+		return ""
+	}
+	following := ring.killed && !ring.IsEmpty() &&
+		ring.killValue == buffer.currentValue &&
+		ring.killPosition == buffer.currentPosition
+	backward := end == buffer.currentPosition
+	removed := buffer.replaceRange(start, end, "")
+	// Hidden text is never put where it could be yanked into another buffer
+	if buffer.masked {
+		return removed
+	}
+	if following {
+		ring.appendKill(removed, backward)
+	} else {
+		ring.Push(removed)
+	}
+	ring.killValue = buffer.currentValue
+	ring.killPosition = buffer.currentPosition
+	ring.killed = true
+	return removed
+}
+
+// Removes from the cursor to the end of the next word, returning the removed text
+func (buffer *Buffer) KillWordForward(ring *KillRing) string {
+	return buffer.kill(buffer.currentPosition, buffer.nextWordEnd(), ring)
+}
+
+// Removes from the start of the previous word to the cursor, returning the removed text
+func (buffer *Buffer) KillWordBackward(ring *KillRing) string {
+	return buffer.kill(buffer.previousWordStart(), buffer.currentPosition, ring)
+}
+
+// Removes from the start of the current line to the cursor, returning the removed text
+func (buffer *Buffer) KillToLineStart(ring *KillRing) string {
+	return buffer.kill(buffer.lineStart(), buffer.currentPosition, ring)
+}
+
+// Removes from the cursor to the end of the line, or the newline if already there
+func (buffer *Buffer) KillToLineEnd(ring *KillRing) string {
+	end := buffer.lineEnd()
+	if end == buffer.currentPosition && end < len(buffer.currentValue) {
+		end += 1
+	}
+	return buffer.kill(buffer.currentPosition, end, ring)
+}
+
+// Removes the whole line the cursor is on along with its newline
+func (buffer *Buffer) KillLine(ring *KillRing) string {
+	start := buffer.lineStart()
+	end := buffer.lineEnd()
+	if end < len(buffer.currentValue) {
+		end += 1
+	} else if start > 0 {
+		start -= 1
+	}
+	return buffer.kill(start, end, ring)
+}
+
+// Inserts the most recent kill at the cursor if the constraints allow it
+func (buffer *Buffer) Yank(ring *KillRing) string {
+	if ring.IsEmpty() {
+		return ""
+	}
+	yanked, accepted := buffer.constrainReplacing(ring.entries[0], 0)
+	if !accepted {
+		return ""
+	}
+	ring.index = 0
+	start := buffer.currentPosition
+	buffer.replaceRange(start, start, yanked)
+	ring.yankStart = start
+	ring.yankEnd = buffer.currentPosition
+	ring.yanked = true
+	ring.killed = false
+	return yanked
+}
+
+// Replaces the text from the last yank with the next older kill
+func (buffer *Buffer) YankPop(ring *KillRing) string {
+	if !ring.yanked ||
+		ring.yankEnd != buffer.currentPosition ||
+		ring.yankEnd > len(buffer.currentValue) ||
+		buffer.currentValue[ring.yankStart:ring.yankEnd] != buffer.transformed(ring.Current()) {
+		ring.yanked = false
+		return ""
+	}
+	next := ring.entries[(ring.index+1)%len(ring.entries)]
+	yanked, accepted := buffer.constrainReplacing(
+		next,
+		utf8.RuneCountInString(buffer.currentValue[ring.yankStart:ring.yankEnd]),
+	)
+	if !accepted {
+		return ""
+	}
+	ring.rotate()
+	buffer.replaceRange(ring.yankStart, ring.yankEnd, yanked)
+	ring.yankEnd = buffer.currentPosition
+	return yanked
+}
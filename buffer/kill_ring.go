@@ -0,0 +1,76 @@
+package buffer
+
+const defaultKillRingSize = 60
+
+// KillRing holds killed text to be yanked back, the most recent kill first
+type KillRing struct {
+	entries []string
+	size    int
+	index   int
+
+	// Bounds of the most recent yank, used to decide if a yank-pop is allowed
+	yankStart int
+	yankEnd   int
+	yanked    bool
+
+	// Buffer as the most recent kill left it, so a kill straight after joins its entry
+	killValue    string
+	killPosition int
+	killed       bool
+}
+
+func NewKillRing() KillRing {
+	return NewKillRingWithSize(defaultKillRingSize)
+}
+
+func NewKillRingWithSize(size int) KillRing {
+	return KillRing{
+		entries: []string{},
+		size:    size,
+	}
+}
+
+func (ring KillRing) IsEmpty() bool {
+	return len(ring.entries) == 0
+}
+
+// Pushes killed text onto the front of the ring, dropping the oldest when full
+func (ring *KillRing) Push(input string) {
+	if input == "" {
+		return
+	}
+	ring.entries = append([]string{input}, ring.entries...)
+	if ring.size > 0 && len(ring.entries) > ring.size {
+		ring.entries = ring.entries[:ring.size]
+	}
+	ring.index = 0
+	ring.yanked = false
+	ring.killed = false
+}
+
+// Joins killed text onto the most recent entry, before it for a kill backwards
+func (ring *KillRing) appendKill(input string, backward bool) {
+	if backward {
+		ring.entries[0] = input + ring.entries[0]
+	} else {
+		ring.entries[0] += input
+	}
+	ring.index = 0
+	ring.yanked = false
+}
+
+// Returns the entry that the next yank will insert
+func (ring KillRing) Current() string {
+	if ring.IsEmpty() {
+		return ""
+	}
+	return ring.entries[ring.index]
+}
+
+func (ring *KillRing) rotate() string {
+	if ring.IsEmpty() {
+		return ""
+	}
+	ring.index = (ring.index + 1) % len(ring.entries)
+	return ring.entries[ring.index]
+}
@@ -0,0 +1,214 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKill(t *testing.T) {
+	trials := []struct {
+		description     string
+		startingBuffer  Buffer
+		kill            func(*Buffer, *KillRing) string
+		expectedRemoved string
+		expectedOutput  Buffer
+	}{
+		{
+			description: "Kill word forward from middle of line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello world, this",
+				currentPosition: 5,
+			},
+			kill:            (*Buffer).KillWordForward,
+			expectedRemoved: " world,",
+			expectedOutput: Buffer{
+				currentValue:    "Hello this",
+				currentPosition: 5,
+			},
+		},
+		{
+			description: "Kill word backward from end of line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello world ",
+				currentPosition: 12,
+			},
+			kill:            (*Buffer).KillWordBackward,
+			expectedRemoved: "world ",
+			expectedOutput: Buffer{
+				currentValue:    "Hello ",
+				currentPosition: 6,
+			},
+		},
+		{
+			description: "Kill word backward at start does nothing",
+			startingBuffer: Buffer{
+				currentValue:    "Hello",
+				currentPosition: 0,
+			},
+			kill:            (*Buffer).KillWordBackward,
+			expectedRemoved: "",
+			expectedOutput: Buffer{
+				currentValue:    "Hello",
+				currentPosition: 0,
+			},
+		},
+		{
+			description: "Kill to line start on second line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello\nworld",
+				currentPosition: 9,
+			},
+			kill:            (*Buffer).KillToLineStart,
+			expectedRemoved: "wor",
+			expectedOutput: Buffer{
+				currentValue:    "Hello\nld",
+				currentPosition: 6,
+			},
+		},
+		{
+			description: "Kill to line end on first line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello\nworld",
+				currentPosition: 2,
+			},
+			kill:            (*Buffer).KillToLineEnd,
+			expectedRemoved: "llo",
+			expectedOutput: Buffer{
+				currentValue:    "He\nworld",
+				currentPosition: 2,
+			},
+		},
+		{
+			description: "Kill to line end at end of line removes newline",
+			startingBuffer: Buffer{
+				currentValue:    "Hello\nworld",
+				currentPosition: 5,
+			},
+			kill:            (*Buffer).KillToLineEnd,
+			expectedRemoved: "\n",
+			expectedOutput: Buffer{
+				currentValue:    "Helloworld",
+				currentPosition: 5,
+			},
+		},
+		{
+			description: "Kill whole first line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello\nworld",
+				currentPosition: 3,
+			},
+			kill:            (*Buffer).KillLine,
+			expectedRemoved: "Hello\n",
+			expectedOutput: Buffer{
+				currentValue:    "world",
+				currentPosition: 0,
+			},
+		},
+		{
+			description: "Kill whole last line",
+			startingBuffer: Buffer{
+				currentValue:    "Hello\nworld",
+				currentPosition: 8,
+			},
+			kill:            (*Buffer).KillLine,
+			expectedRemoved: "\nworld",
+			expectedOutput: Buffer{
+				currentValue:    "Hello",
+				currentPosition: 5,
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			ring := NewKillRing()
+			actualOutput := trial.startingBuffer
+			actualRemoved := trial.kill(&actualOutput, &ring)
+			assert.Equal(tt, trial.expectedRemoved, actualRemoved)
+			assert.Equal(tt, trial.expectedOutput, actualOutput)
+			assert.Equal(tt, trial.expectedRemoved, ring.Current())
+		})
+	}
+}
+
+func TestYank(t *testing.T) {
+	ring := NewKillRing()
+	ring.Push("three")
+	ring.Push("two ")
+	buffer := NewBufferWithString("one ")
+
+	assert.Equal(t, "two ", buffer.Yank(&ring))
+	assert.Equal(t, "one two ", buffer.currentValue)
+
+	assert.Equal(t, "three", buffer.YankPop(&ring))
+	assert.Equal(t, "one three", buffer.currentValue)
+	assert.Equal(t, 9, buffer.currentPosition)
+
+	assert.Equal(t, "two ", buffer.YankPop(&ring))
+	assert.Equal(t, "one two ", buffer.currentValue)
+
+	buffer.AddCharacter('x')
+	assert.Equal(t, "", buffer.YankPop(&ring))
+	assert.Equal(t, "one two x", buffer.currentValue)
+}
+
+func TestConsecutiveKillsJoin(t *testing.T) {
+	trials := []struct {
+		description     string
+		startingBuffer  Buffer
+		startingCursor  int
+		kill            func(*Buffer, *KillRing)
+		expectedEntries []string
+	}{
+		{
+			description:    "Backward kills join in front",
+			startingBuffer: NewBufferWithString("one two three"),
+			startingCursor: 13,
+			kill: func(buffer *Buffer, ring *KillRing) {
+				buffer.KillWordBackward(ring)
+				buffer.KillWordBackward(ring)
+			},
+			expectedEntries: []string{"two three"},
+		},
+		{
+			description:    "Forward kills join behind",
+			startingBuffer: NewBufferWithString("a b c"),
+			startingCursor: 0,
+			kill: func(buffer *Buffer, ring *KillRing) {
+				buffer.KillWordForward(ring)
+				buffer.KillWordForward(ring)
+			},
+			expectedEntries: []string{"a b"},
+		},
+		{
+			description:    "Editing in between starts a new entry",
+			startingBuffer: NewBufferWithString("one two"),
+			startingCursor: 7,
+			kill: func(buffer *Buffer, ring *KillRing) {
+				buffer.KillWordBackward(ring)
+				buffer.AddCharacter('x')
+				buffer.KillWordBackward(ring)
+			},
+			expectedEntries: []string{"x", "two"},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			ring := NewKillRing()
+			buffer := trial.startingBuffer
+			buffer.SetCursor(trial.startingCursor)
+			trial.kill(&buffer, &ring)
+			assert.Equal(tt, trial.expectedEntries, ring.entries)
+		})
+	}
+}
+
+func TestKillRingSize(t *testing.T) {
+	ring := NewKillRingWithSize(2)
+	ring.Push("first")
+	ring.Push("second")
+	ring.Push("third")
+	assert.Equal(t, []string{"third", "second"}, ring.entries)
+}
@@ -0,0 +1,166 @@
+package buffer
+
+import (
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+// A single row of the buffer as drawn, a whole line or the part that fits the width
+type row struct {
+	start int
+	end   int
+	// Columns taken up before the first character, such as by the prefix
+	offset     int
+	lastOfLine bool
+}
+
+// Index of the first character of the line the cursor is on
+func (buffer Buffer) lineStart() int {
+	start := buffer.currentPosition
+	for start > 0 && buffer.currentValue[start-1] != '\n' {
+		start -= 1
+	}
+	return start
+}
+
+// Index of the newline ending the line the cursor is on, or the buffer length
+func (buffer Buffer) lineEnd() int {
+	end := buffer.currentPosition
+	for end < len(buffer.currentValue) && buffer.currentValue[end] != '\n' {
+		end += 1
+	}
+	return end
+}
+
+// Splits the buffer into rows wrapped at width, or only on newlines if width is zero.
+// Rows are wrapped by display width in the same way as the terminal draws them.
+func (buffer Buffer) rows(width int) []row {
+	output := []row{}
+	start := 0
+	prefix := buffer.prefix
+	for {
+		end := start
+		for end < len(buffer.currentValue) && buffer.currentValue[end] != '\n' {
+			end += 1
+		}
+
+		rowStart := start
+		column := prefixColumns(prefix, width)
+		rowOffset := column
+		for i, character := range buffer.currentValue[start:end] {
+			characterWidth := utils.RuneWidth(character)
+			if width > 0 && column > 0 && column+characterWidth > width {
+				output = append(output, row{
+					start:  rowStart,
+					end:    start + i,
+					offset: rowOffset,
+				})
+				rowStart = start + i
+				column = 0
+				rowOffset = 0
+			}
+			column += characterWidth
+		}
+		output = append(output, row{
+			start:      rowStart,
+			end:        end,
+			offset:     rowOffset,
+			lastOfLine: true,
+		})
+
+		if end >= len(buffer.currentValue) {
+			return output
+		}
+		start = end + 1
+		prefix = buffer.continuationPrefix
+	}
+}
+
+// Columns the prefix takes up on the row the text after it starts on
+func prefixColumns(prefix string, width int) int {
+	column := 0
+	for _, character := range prefix {
+		characterWidth := utils.RuneWidth(character)
+		if character == '\n' || (width > 0 && column > 0 && column+characterWidth > width) {
+			column = 0
+		}
+		if character != '\n' {
+			column += characterWidth
+		}
+	}
+	return column
+}
+
+// Index of the row holding the position, the later row when on a boundary
+func rowOf(rows []row, position int) int {
+	for i, r := range rows {
+		if position >= r.start && (position < r.end || (r.lastOfLine && position == r.end)) {
+			return i
+		}
+	}
+	return len(rows) - 1
+}
+
+// Moves the cursor delta rows, keeping its column, or returns false if there is none
+func (buffer *Buffer) moveVertically(delta int, width int) bool {
+	rows := buffer.rows(width)
+	current := rowOf(rows, buffer.currentPosition)
+	target := current + delta
+	if target < 0 || target >= len(rows) {
+		return false
+	}
+
+	if !buffer.hasGoal || buffer.goalPosition != buffer.currentPosition {
+		buffer.goalColumn = rows[current].offset + utils.DisplayWidth(
+			buffer.currentValue[rows[current].start:buffer.currentPosition],
+		)
+	}
+
+	// The cursor lands on the character covering the goal column, or the last one
+	r := rows[target]
+	position := r.start
+	column := r.offset
+	for i, character := range buffer.currentValue[r.start:r.end] {
+		column += utils.RuneWidth(character)
+		if column > buffer.goalColumn {
+			break
+		}
+		position = r.start + i + utf8.RuneLen(character)
+	}
+	if !r.lastOfLine && position == r.end {
+		_, size := utf8.DecodeLastRuneInString(buffer.currentValue[r.start:r.end])
+		position -= size
+	}
+
+	buffer.currentPosition = position
+	buffer.goalPosition = position
+	buffer.hasGoal = true
+	return true
+}
+
+// Moves the cursor up a row, returning false if already on the first row
+func (buffer *Buffer) CursorUp(width int) bool {
+	return buffer.moveVertically(-1, width)
+}
+
+// Moves the cursor down a row, returning false if already on the last row
+func (buffer *Buffer) CursorDown(width int) bool {
+	return buffer.moveVertically(1, width)
+}
+
+func (buffer *Buffer) CursorToLineStart() {
+	buffer.currentPosition = buffer.lineStart()
+}
+
+func (buffer *Buffer) CursorToLineEnd() {
+	buffer.currentPosition = buffer.lineEnd()
+}
+
+func (buffer *Buffer) CursorToStart() {
+	buffer.currentPosition = 0
+}
+
+func (buffer *Buffer) CursorToEnd() {
+	buffer.currentPosition = len(buffer.currentValue)
+}
@@ -0,0 +1,148 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorVertical(t *testing.T) {
+	trials := []struct {
+		description      string
+		prefix           string
+		value            string
+		startingPosition int
+		width            int
+		moves            []int
+		expectedPosition int
+		expectedMoved    bool
+	}{
+		{
+			description:      "Up from first line does not move",
+			value:            "hello\nworld",
+			startingPosition: 3,
+			moves:            []int{-1},
+			expectedPosition: 3,
+			expectedMoved:    false,
+		},
+		{
+			description:      "Down a logical line keeps column",
+			value:            "hello\nworld",
+			startingPosition: 3,
+			moves:            []int{1},
+			expectedPosition: 9,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Down onto a shorter line clamps to its end",
+			value:            "hello\nhi",
+			startingPosition: 4,
+			moves:            []int{1},
+			expectedPosition: 8,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Goal column is kept across a short line",
+			value:            "hello\nhi\nworld",
+			startingPosition: 4,
+			moves:            []int{1, 1},
+			expectedPosition: 13,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Up a wrapped row",
+			value:            "0123456789abcdefghij",
+			startingPosition: 13,
+			width:            10,
+			moves:            []int{-1},
+			expectedPosition: 3,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Down a wrapped row with prefix",
+			prefix:           ">> ",
+			value:            "0123456789abcdefghij",
+			startingPosition: 2,
+			width:            10,
+			moves:            []int{1},
+			expectedPosition: 12,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Up from a line onto the prefixed first line",
+			prefix:           ">> ",
+			value:            "hello\nworld",
+			startingPosition: 6,
+			moves:            []int{-1},
+			expectedPosition: 0,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Up onto multibyte characters lands between them",
+			value:            "ééééé\nabcde",
+			startingPosition: 14,
+			width:            80,
+			moves:            []int{-1},
+			expectedPosition: 6,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Up onto wide characters keeps to the column",
+			value:            "日本語\nabcde",
+			startingPosition: 13,
+			moves:            []int{-1},
+			expectedPosition: 3,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Wide characters wrap whole",
+			value:            "日本語日",
+			startingPosition: 12,
+			width:            5,
+			moves:            []int{-1},
+			expectedPosition: 3,
+			expectedMoved:    true,
+		},
+		{
+			description:      "Down a wrapped row of wide characters",
+			prefix:           "> ",
+			value:            "日本語日本",
+			startingPosition: 3,
+			width:            7,
+			moves:            []int{1},
+			expectedPosition: 12,
+			expectedMoved:    true,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buffer := NewBufferWithString(trial.value)
+			buffer.SetPrefix(trial.prefix).SetCursor(trial.startingPosition)
+			moved := false
+			for _, move := range trial.moves {
+				if move < 0 {
+					moved = buffer.CursorUp(trial.width)
+				} else {
+					moved = buffer.CursorDown(trial.width)
+				}
+			}
+			assert.Equal(tt, trial.expectedPosition, buffer.currentPosition)
+			assert.Equal(tt, trial.expectedMoved, moved)
+		})
+	}
+}
+
+func TestCursorLineEnds(t *testing.T) {
+	buffer := NewBufferWithString("hello\nworld\nagain")
+	buffer.SetCursor(8)
+
+	buffer.CursorToLineStart()
+	assert.Equal(t, 6, buffer.currentPosition)
+	buffer.CursorToLineEnd()
+	assert.Equal(t, 11, buffer.currentPosition)
+	buffer.CursorToStart()
+	assert.Equal(t, 0, buffer.currentPosition)
+	buffer.CursorToEnd()
+	assert.Equal(t, 17, buffer.currentPosition)
+}
@@ -0,0 +1,84 @@
+package buffer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+// Stands in for each hidden character in the buffer's value
+const maskPlaceholder = "*"
+
+// Hides the buffer's text behind mask, or draws nothing if mask is 0
+func (buffer *Buffer) SetMask(mask rune) *Buffer {
+	if !buffer.masked {
+		buffer.currentPosition = utf8.RuneCountInString(
+			buffer.currentValue[:buffer.currentPosition],
+		)
+		buffer.secret = []rune(buffer.currentValue)
+		buffer.currentValue = strings.Repeat(maskPlaceholder, len(buffer.secret))
+		buffer.ClearPrevious()
+	}
+	buffer.masked = true
+	buffer.mask = mask
+	return buffer
+}
+
+func (buffer Buffer) IsMasked() bool {
+	return buffer.masked
+}
+
+// Whether the buffer is masked with nothing drawn for its characters
+func (buffer Buffer) HidesInput() bool {
+	return buffer.masked && buffer.mask == 0
+}
+
+// Returns a copy of the hidden text, which the caller should zero when done
+func (buffer Buffer) Secret() []byte {
+	output := make([]byte, 0, len(buffer.secret)*utf8.UTFMax)
+	for _, character := range buffer.secret {
+		output = utf8.AppendRune(output, character)
+	}
+	return output
+}
+
+// The number of hidden characters, and whether that has changed since the last draw
+func (buffer Buffer) MaskedLengthChanged() (int, bool) {
+	return len(buffer.currentValue), len(buffer.currentValue) != len(buffer.previousValue)
+}
+
+// Replaces hidden characters between start and end, zeroing the old storage
+func (buffer *Buffer) replaceSecret(start int, end int, input string) string {
+	inserted := []rune(input)
+	updated := make([]rune, 0, len(buffer.secret)-(end-start)+len(inserted))
+	updated = append(updated, buffer.secret[:start]...)
+	updated = append(updated, inserted...)
+	updated = append(updated, buffer.secret[end:]...)
+	zeroRunes(buffer.secret)
+	zeroRunes(inserted)
+	buffer.secret = updated
+
+	removed := buffer.currentValue[start:end]
+	buffer.currentValue = buffer.currentValue[:start] +
+		strings.Repeat(maskPlaceholder, len(inserted)) +
+		buffer.currentValue[end:]
+	buffer.currentPosition = start + len(inserted)
+	return removed
+}
+
+func zeroRunes(input []rune) {
+	for i := range input {
+		input[i] = 0
+	}
+}
+
+// Draws a masked value, with the position moved to match the width of the mask
+func (buffer Buffer) maskValue(value string, position int) (string, int) {
+	if buffer.mask == 0 {
+		return "", 0
+	}
+	mask := string(buffer.mask)
+	return strings.Repeat(mask, len(value)),
+		utf8.RuneCountInString(value[:utils.IntMin(position, len(value))]) * len(mask)
+}
@@ -0,0 +1,112 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskedBuffer(t *testing.T) {
+	trials := []struct {
+		description      string
+		mask             rune
+		edit             func(*Buffer)
+		expectedSecret   string
+		expectedOutput   string
+		expectedPosition int
+	}{
+		{
+			description: "Typed characters are masked",
+			mask:        '*',
+			edit: func(buffer *Buffer) {
+				buffer.AddString("pä")
+				buffer.AddCharacter('s')
+			},
+			expectedSecret:   "päs",
+			expectedOutput:   "Password: ***",
+			expectedPosition: 13,
+		},
+		{
+			description: "Multi-byte mask moves the cursor a whole mask per character",
+			mask:        '•',
+			edit: func(buffer *Buffer) {
+				buffer.AddString("abc")
+				buffer.RetreatCursor(1)
+			},
+			expectedSecret:   "abc",
+			expectedOutput:   "Password: •••",
+			expectedPosition: 16,
+		},
+		{
+			description: "Multi-byte secret and mask",
+			mask:        '•',
+			edit: func(buffer *Buffer) {
+				buffer.AddString("日本語")
+				buffer.RetreatCursor(1)
+			},
+			expectedSecret:   "日本語",
+			expectedOutput:   "Password: •••",
+			expectedPosition: 16,
+		},
+		{
+			description: "Removing and inserting in the middle",
+			mask:        '*',
+			edit: func(buffer *Buffer) {
+				buffer.AddString("secret")
+				buffer.RetreatCursor(2)
+				buffer.RemoveCharacter()
+				buffer.AddCharacter('R')
+			},
+			expectedSecret:   "secRet",
+			expectedOutput:   "Password: ******",
+			expectedPosition: 14,
+		},
+		{
+			description: "No mask draws nothing",
+			mask:        0,
+			edit: func(buffer *Buffer) {
+				buffer.AddString("hidden")
+			},
+			expectedSecret:   "hidden",
+			expectedOutput:   "Password: ",
+			expectedPosition: 10,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buffer := NewBuffer()
+			buffer.SetPrefix("Password: ").SetMask(trial.mask)
+			trial.edit(&buffer)
+
+			actualOutput, actualPosition := buffer.Output()
+			assert.Equal(tt, trial.expectedSecret, string(buffer.Secret()))
+			assert.Equal(tt, trial.expectedOutput, actualOutput)
+			assert.Equal(tt, trial.expectedPosition, actualPosition)
+		})
+	}
+}
+
+func TestMaskedBufferClearZeroesSecret(t *testing.T) {
+	buffer := NewBuffer()
+	buffer.SetMask('*')
+	buffer.AddString("hunter2")
+	storage := buffer.secret
+
+	buffer.Clear()
+
+	assert.Equal(t, make([]rune, len(storage)), storage)
+	assert.Equal(t, 0, len(buffer.Secret()))
+}
+
+func TestMaskedBufferKillIsNotYankable(t *testing.T) {
+	ring := NewKillRing()
+	buffer := NewBuffer()
+	buffer.SetMask('*')
+	buffer.AddString("hunter2")
+
+	removed := buffer.KillWordBackward(&ring)
+
+	assert.Equal(t, "*******", removed)
+	assert.True(t, ring.IsEmpty())
+}
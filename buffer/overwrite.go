@@ -0,0 +1,65 @@
+package buffer
+
+import "unicode/utf8"
+
+// Switches between inserting typed text and overwriting the text under the cursor
+func (buffer *Buffer) SetOverwrite(overwrite bool) *Buffer {
+	buffer.overwrite = overwrite
+	buffer.overwritten = nil
+	return buffer
+}
+
+func (buffer Buffer) IsOverwrite() bool {
+	return buffer.overwrite
+}
+
+// Switches between insert and overwrite, returning true if the buffer now overwrites
+func (buffer *Buffer) ToggleOverwrite() bool {
+	buffer.SetOverwrite(!buffer.overwrite)
+	return buffer.overwrite
+}
+
+// Replaces the character under the cursor, keeping the original for backspace
+func (buffer *Buffer) overwriteCharacter(character rune) {
+	if buffer.overwritePosition != buffer.currentPosition {
+		buffer.overwritten = nil
+	}
+	end := buffer.currentPosition
+	if end < len(buffer.currentValue) && buffer.currentValue[end] != '\n' {
+		if buffer.masked {
+			end += 1
+		} else {
+			_, size := utf8.DecodeRuneInString(buffer.currentValue[end:])
+			end += size
+		}
+	}
+	if character == '\n' {
+		end = buffer.currentPosition
+	}
+	removed := buffer.replaceRange(buffer.currentPosition, end, string(character))
+	// Hidden text is never kept around to be restored
+	if buffer.masked {
+		removed = ""
+	}
+	buffer.overwritten = append(buffer.overwritten, removed)
+	buffer.overwritePosition = buffer.currentPosition
+}
+
+// Puts back the last overwritten character, returning false if there is none
+func (buffer *Buffer) restoreOverwritten() bool {
+	count := len(buffer.overwritten)
+	if count == 0 || buffer.overwritePosition != buffer.currentPosition {
+		return false
+	}
+	start := buffer.currentPosition - 1
+	if !buffer.masked {
+		_, size := utf8.DecodeLastRuneInString(buffer.currentValue[:buffer.currentPosition])
+		start = buffer.currentPosition - size
+	}
+	original := buffer.overwritten[count-1]
+	buffer.overwritten = buffer.overwritten[:count-1]
+	buffer.replaceRange(start, buffer.currentPosition, original)
+	buffer.currentPosition = start
+	buffer.overwritePosition = start
+	return true
+}
@@ -0,0 +1,112 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverwrite(t *testing.T) {
+	trials := []struct {
+		description      string
+		initial          string
+		cursor           int
+		edit             func(*Buffer)
+		expectedValue    string
+		expectedPosition int
+	}{
+		{
+			description: "Typing replaces the characters under the cursor",
+			initial:     "hello",
+			cursor:      1,
+			edit: func(buffer *Buffer) {
+				buffer.AddCharacter('a')
+				buffer.AddString("ms")
+			},
+			expectedValue:    "hamso",
+			expectedPosition: 4,
+		},
+		{
+			description: "Typing past the end adds to the line",
+			initial:     "ab\ncd",
+			cursor:      1,
+			edit: func(buffer *Buffer) {
+				buffer.AddString("xyz")
+			},
+			expectedValue:    "axyz\ncd",
+			expectedPosition: 4,
+		},
+		{
+			description: "Multi-byte characters are replaced whole",
+			initial:     "héllo",
+			cursor:      1,
+			edit: func(buffer *Buffer) {
+				buffer.AddCharacter('e')
+			},
+			expectedValue:    "hello",
+			expectedPosition: 2,
+		},
+		{
+			description: "Backspace restores what was overwritten",
+			initial:     "hello",
+			cursor:      3,
+			edit: func(buffer *Buffer) {
+				buffer.AddString("pXY")
+				buffer.RemoveCharacter()
+				buffer.RemoveCharacter()
+				buffer.RemoveCharacter()
+			},
+			expectedValue:    "hello",
+			expectedPosition: 3,
+		},
+		{
+			description: "Backspace removes once the originals run out",
+			initial:     "hello",
+			cursor:      3,
+			edit: func(buffer *Buffer) {
+				buffer.AddCharacter('L')
+				buffer.RemoveCharacter()
+				buffer.RemoveCharacter()
+			},
+			expectedValue:    "helo",
+			expectedPosition: 2,
+		},
+		{
+			description: "Typing elsewhere forgets the originals",
+			initial:     "hello",
+			cursor:      0,
+			edit: func(buffer *Buffer) {
+				buffer.AddCharacter('j')
+				buffer.SetCursor(3)
+				buffer.AddCharacter('L')
+				buffer.RemoveCharacter()
+				buffer.RemoveCharacter()
+			},
+			expectedValue:    "jelo",
+			expectedPosition: 2,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buffer := NewBufferWithString(trial.initial)
+			buffer.SetCursor(trial.cursor).SetOverwrite(true)
+			trial.edit(&buffer)
+			value, position := buffer.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedValue, value)
+			assert.Equal(tt, trial.expectedPosition, position)
+		})
+	}
+}
+
+func TestToggleOverwrite(t *testing.T) {
+	buffer := NewBufferWithString("ab")
+	buffer.SetCursor(0)
+	assert.True(t, buffer.ToggleOverwrite())
+	buffer.AddCharacter('x')
+	assert.False(t, buffer.ToggleOverwrite())
+	buffer.AddCharacter('y')
+	value, _ := buffer.OutputWithoutPrefix()
+	assert.Equal(t, "xyb", value)
+	assert.False(t, buffer.IsOverwrite())
+}
@@ -0,0 +1,51 @@
+package completion
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Candidate is a possible completion with an optional description to read out
+type Candidate struct {
+	Value       string
+	Description string
+}
+
+// Completer offers candidates to replace value[start:cursor], returning start with them
+type Completer interface {
+	Complete(value string, cursor int) ([]Candidate, int)
+}
+
+// Index of the start of the white space delimited word ending at the cursor
+func WordStart(value string, cursor int) int {
+	start := cursor
+	for start > 0 && !isSpace(value[start-1]) {
+		start -= 1
+	}
+	return start
+}
+
+func isSpace(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\n'
+}
+
+// The longest prefix shared by the values of all candidates
+func CommonPrefix(candidates []Candidate) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	prefix := candidates[0].Value
+	for _, candidate := range candidates[1:] {
+		// Trimmed a whole character at a time so no partial character is left
+		for !strings.HasPrefix(candidate.Value, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// Suggester offers the rest of a line as it is typed, or an empty string for none
+type Suggester interface {
+	Suggest(value string) string
+}
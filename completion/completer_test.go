@@ -0,0 +1,128 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommonPrefix(t *testing.T) {
+	trials := []struct {
+		description    string
+		candidates     []Candidate
+		expectedOutput string
+	}{
+		{
+			description:    "No candidates",
+			candidates:     []Candidate{},
+			expectedOutput: "",
+		},
+		{
+			description:    "Single candidate",
+			candidates:     []Candidate{{Value: "hello"}},
+			expectedOutput: "hello",
+		},
+		{
+			description: "Shared prefix",
+			candidates: []Candidate{
+				{Value: "install"},
+				{Value: "instance"},
+				{Value: "inspect"},
+			},
+			expectedOutput: "ins",
+		},
+		{
+			description: "Nothing shared",
+			candidates: []Candidate{
+				{Value: "add"},
+				{Value: "remove"},
+			},
+			expectedOutput: "",
+		},
+		{
+			description: "Differing multibyte characters are left out whole",
+			candidates: []Candidate{
+				{Value: "café"},
+				{Value: "cafè"},
+			},
+			expectedOutput: "caf",
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			assert.Equal(tt, trial.expectedOutput, CommonPrefix(trial.candidates))
+		})
+	}
+}
+
+func TestWordListCompleter(t *testing.T) {
+	completer := NewWordListCompleter("install", "instance", "remove")
+
+	candidates, start := completer.Complete("sudo inst now", 9)
+	assert.Equal(t, []Candidate{{Value: "install"}, {Value: "instance"}}, candidates)
+	assert.Equal(t, 5, start)
+
+	candidates, start = completer.Complete("", 0)
+	assert.Equal(t, 3, len(candidates))
+	assert.Equal(t, 0, start)
+}
+
+func TestWordListSuggester(t *testing.T) {
+	trials := []struct {
+		description    string
+		input          string
+		expectedOutput string
+	}{
+		{
+			description:    "Rest of the first matching word",
+			input:          "sudo inst",
+			expectedOutput: "all",
+		},
+		{
+			description:    "No word typed",
+			input:          "sudo ",
+			expectedOutput: "",
+		},
+		{
+			description:    "Word already complete",
+			input:          "remove",
+			expectedOutput: "",
+		},
+	}
+
+	completer := NewWordListCompleter("install", "instance", "remove")
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			assert.Equal(tt, trial.expectedOutput, completer.Suggest(trial.input))
+		})
+	}
+}
+
+func TestPathCompleter(t *testing.T) {
+	directory := t.TempDir()
+	os.Mkdir(filepath.Join(directory, "docs"), 0o755)
+	os.WriteFile(filepath.Join(directory, "docs", "readme.md"), []byte{}, 0o644)
+	os.WriteFile(filepath.Join(directory, "download.txt"), []byte{}, 0o644)
+	os.WriteFile(filepath.Join(directory, ".hidden"), []byte{}, 0o644)
+
+	completer := PathCompleter{Directory: directory}
+
+	candidates, start := completer.Complete("cat do", 6)
+	assert.Equal(t, []Candidate{
+		{Value: "docs" + string(filepath.Separator), Description: "directory"},
+		{Value: "download.txt"},
+	}, candidates)
+	assert.Equal(t, 4, start)
+
+	candidates, _ = completer.Complete("docs/re", 7)
+	assert.Equal(t, []Candidate{{Value: "docs/readme.md"}}, candidates)
+
+	candidates, _ = completer.Complete("", 0)
+	assert.Equal(t, 2, len(candidates))
+
+	candidates, _ = completer.Complete(".h", 2)
+	assert.Equal(t, []Candidate{{Value: ".hidden"}}, candidates)
+}
@@ -0,0 +1,59 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PathCompleter completes file paths, resolving relative ones against Directory
+type PathCompleter struct {
+	Directory string
+}
+
+func (completer PathCompleter) Complete(value string, cursor int) ([]Candidate, int) {
+	start := WordStart(value, cursor)
+	word := value[start:cursor]
+
+	directory, base := filepath.Split(word)
+	searchDirectory := directory
+	if strings.HasPrefix(searchDirectory, "~/") {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			searchDirectory = filepath.Join(home, searchDirectory[2:])
+		}
+	}
+	if searchDirectory == "" {
+		searchDirectory = "."
+	}
+	if !filepath.IsAbs(searchDirectory) && completer.Directory != "" {
+		searchDirectory = filepath.Join(completer.Directory, searchDirectory)
+	}
+
+	entries, err := os.ReadDir(searchDirectory)
+	if err != nil {
+		return []Candidate{}, start
+	}
+
+	output := []Candidate{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		candidate := Candidate{Value: directory + name}
+		if entry.IsDir() {
+			candidate.Value += string(filepath.Separator)
+			candidate.Description = "directory"
+		}
+		output = append(output, candidate)
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Value < output[j].Value
+	})
+	return output, start
+}
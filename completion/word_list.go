@@ -0,0 +1,48 @@
+package completion
+
+import (
+	"strings"
+)
+
+// WordListCompleter completes the word before the cursor from a fixed list of words
+type WordListCompleter struct {
+	words []Candidate
+}
+
+func NewWordListCompleter(words ...string) WordListCompleter {
+	candidates := make([]Candidate, len(words))
+	for i, word := range words {
+		candidates[i] = Candidate{Value: word}
+	}
+	return WordListCompleter{words: candidates}
+}
+
+func NewDescribedWordListCompleter(words []Candidate) WordListCompleter {
+	return WordListCompleter{words: words}
+}
+
+func (completer WordListCompleter) Complete(value string, cursor int) ([]Candidate, int) {
+	start := WordStart(value, cursor)
+	word := value[start:cursor]
+	output := []Candidate{}
+	for _, candidate := range completer.words {
+		if strings.HasPrefix(candidate.Value, word) {
+			output = append(output, candidate)
+		}
+	}
+	return output, start
+}
+
+// Suggests the rest of the first word in the list starting with the last word typed
+func (completer WordListCompleter) Suggest(value string) string {
+	word := value[WordStart(value, len(value)):]
+	if word == "" {
+		return ""
+	}
+	for _, candidate := range completer.words {
+		if len(candidate.Value) > len(word) && strings.HasPrefix(candidate.Value, word) {
+			return candidate.Value[len(word):]
+		}
+	}
+	return ""
+}
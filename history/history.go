@@ -1,6 +1,8 @@
 package history
 
 import (
+	"strings"
+
 	"github.com/bekreth/screen_reader_terminal/buffer"
 )
 
@@ -31,3 +33,14 @@ func (history *History) AddBuffer(buffer buffer.Buffer) {
 func (history *History) ReplaceLastBuffer(buffer buffer.Buffer) {
 	history.buffers[history.index] = buffer
 }
+
+// Suggests the rest of the most recent entry that starts with value
+func (history History) Suggest(value string) string {
+	for i := len(history.buffers) - 1; i >= 0; i-- {
+		previous, _ := history.buffers[i].OutputWithoutPrefix()
+		if len(previous) > len(value) && strings.HasPrefix(previous, value) {
+			return previous[len(value):]
+		}
+	}
+	return ""
+}
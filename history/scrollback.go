@@ -0,0 +1,54 @@
+package history
+
+// Scrollback keeps the most recent lines of output, dropping the oldest once it is
+// full, along with where the output of the last command began
+type Scrollback struct {
+	lines []string
+	size  int
+	// Lines dropped from the front, so positions can be kept as counts of every line
+	// ever added
+	dropped      int
+	commandStart int
+}
+
+func NewScrollback(size int) Scrollback {
+	return Scrollback{
+		lines: []string{},
+		size:  size,
+	}
+}
+
+func (scrollback *Scrollback) Add(lines ...string) {
+	scrollback.lines = append(scrollback.lines, lines...)
+	if overflow := len(scrollback.lines) - scrollback.size; overflow > 0 {
+		scrollback.lines = append([]string{}, scrollback.lines[overflow:]...)
+		scrollback.dropped += overflow
+	}
+}
+
+// Marks the next line added as the start of a command's output
+func (scrollback *Scrollback) MarkCommand() {
+	scrollback.commandStart = scrollback.dropped + len(scrollback.lines)
+}
+
+func (scrollback Scrollback) Lines() []string {
+	return scrollback.lines
+}
+
+func (scrollback Scrollback) Len() int {
+	return len(scrollback.lines)
+}
+
+func (scrollback Scrollback) Line(index int) string {
+	return scrollback.lines[index]
+}
+
+// Index of the first line of the last command's output, or of the oldest line kept if
+// that has been dropped.  Equal to Len if the command has printed nothing.
+func (scrollback Scrollback) CommandStart() int {
+	start := scrollback.commandStart - scrollback.dropped
+	if start < 0 {
+		return 0
+	}
+	return start
+}
@@ -0,0 +1,44 @@
+package input
+
+type KeyCode int
+
+const (
+	RUNE      KeyCode = 0
+	ENTER     KeyCode = 1
+	TAB       KeyCode = 2
+	SHIFT_TAB KeyCode = 3
+	BACKSPACE KeyCode = 4
+	ESCAPE    KeyCode = 5
+	UP        KeyCode = 6
+	DOWN      KeyCode = 7
+	LEFT      KeyCode = 8
+	RIGHT     KeyCode = 9
+	HOME      KeyCode = 10
+	END       KeyCode = 11
+	INSERT    KeyCode = 12
+	DELETE    KeyCode = 13
+	PAGE_UP   KeyCode = 14
+	PAGE_DOWN KeyCode = 15
+	// A control chord, with Rune holding the lower case letter, so control+a has a Rune
+	// of 'a'
+	CONTROL KeyCode = 16
+	UNKNOWN KeyCode = 17
+	// A block of text pasted while bracketed paste is enabled, with Text holding it all
+	PASTE KeyCode = 18
+)
+
+// Key is a single key press.  Rune is set for RUNE and CONTROL keys, and Text for PASTE.
+type Key struct {
+	Code KeyCode
+	Rune rune
+	Text string
+}
+
+func RuneKey(character rune) Key {
+	return Key{Code: RUNE, Rune: character}
+}
+
+// KeyReader supplies key presses one at a time, blocking until one is available
+type KeyReader interface {
+	ReadKey() (Key, error)
+}
@@ -0,0 +1,30 @@
+package input
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// Puts the terminal behind fd into raw mode so keys are delivered as they are pressed
+// and are not echoed.  The returned function puts the terminal back as it was.
+func EnableRawMode(fd int) (func() error, error) {
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, err
+	}
+	original := *termios
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP |
+		unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, termios); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return unix.IoctlSetTermios(fd, unix.TCSETS, &original)
+	}, nil
+}
@@ -0,0 +1,126 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+const escape = 0x1B
+
+// Sent by the terminal after pasted text while bracketed paste is enabled
+var pasteEnd = []byte("\x1B[201~")
+
+// StreamReader decodes key presses, including ANSI escape sequences for the arrow and
+// editing keys, from a stream such as os.Stdin.  The terminal must already be in raw
+// mode so keys arrive as they are pressed.
+type StreamReader struct {
+	reader *bufio.Reader
+}
+
+func NewStreamReader(reader io.Reader) *StreamReader {
+	return &StreamReader{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+func (stream *StreamReader) ReadKey() (Key, error) {
+	character, _, err := stream.reader.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch {
+	case character == escape:
+		return stream.readEscape()
+	case character == '\r' || character == '\n':
+		return Key{Code: ENTER}, nil
+	case character == '\t':
+		return Key{Code: TAB}, nil
+	case character == 0x7F || character == 0x08:
+		return Key{Code: BACKSPACE}, nil
+	case character < 0x20:
+		return Key{Code: CONTROL, Rune: 'a' + character - 1}, nil
+	}
+	return RuneKey(character), nil
+}
+
+// Reads the rest of an escape sequence.  An escape with nothing waiting behind it is
+// taken to be the escape key itself, as terminals send a sequence in a single write.
+func (stream *StreamReader) readEscape() (Key, error) {
+	if stream.reader.Buffered() == 0 {
+		return Key{Code: ESCAPE}, nil
+	}
+	introducer, _ := stream.reader.ReadByte()
+	if introducer != '[' && introducer != 'O' {
+		return Key{Code: UNKNOWN}, nil
+	}
+
+	// Parameters are digits and semicolons, ended by a single final byte
+	parameters := []byte{}
+	for {
+		next, err := stream.reader.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if (next >= '0' && next <= '9') || next == ';' {
+			parameters = append(parameters, next)
+			continue
+		}
+		if next == '~' && string(parameters) == "200" {
+			return stream.readPaste()
+		}
+		return decodeSequence(string(parameters), next), nil
+	}
+}
+
+// Reads pasted text up to the end of paste sequence, so it arrives as one key
+func (stream *StreamReader) readPaste() (Key, error) {
+	text := []byte{}
+	for !bytes.HasSuffix(text, pasteEnd) {
+		next, err := stream.reader.ReadByte()
+		if err == io.EOF {
+			return Key{Code: PASTE, Text: string(text)}, nil
+		}
+		if err != nil {
+			return Key{}, err
+		}
+		text = append(text, next)
+	}
+	return Key{Code: PASTE, Text: string(text[:len(text)-len(pasteEnd)])}, nil
+}
+
+func decodeSequence(parameters string, final byte) Key {
+	switch final {
+	case 'A':
+		return Key{Code: UP}
+	case 'B':
+		return Key{Code: DOWN}
+	case 'C':
+		return Key{Code: RIGHT}
+	case 'D':
+		return Key{Code: LEFT}
+	case 'H':
+		return Key{Code: HOME}
+	case 'F':
+		return Key{Code: END}
+	case 'Z':
+		return Key{Code: SHIFT_TAB}
+	case '~':
+		switch parameters {
+		case "1", "7":
+			return Key{Code: HOME}
+		case "2":
+			return Key{Code: INSERT}
+		case "3":
+			return Key{Code: DELETE}
+		case "4", "8":
+			return Key{Code: END}
+		case "5":
+			return Key{Code: PAGE_UP}
+		case "6":
+			return Key{Code: PAGE_DOWN}
+		}
+	}
+	return Key{Code: UNKNOWN}
+}
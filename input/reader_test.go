@@ -0,0 +1,94 @@
+package input
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadKey(t *testing.T) {
+	trials := []struct {
+		description  string
+		input        string
+		expectedKeys []Key
+	}{
+		{
+			description:  "Plain characters",
+			input:        "hé",
+			expectedKeys: []Key{RuneKey('h'), RuneKey('é')},
+		},
+		{
+			description: "Editing keys",
+			input:       "\r\t\x7f\x01",
+			expectedKeys: []Key{
+				{Code: ENTER},
+				{Code: TAB},
+				{Code: BACKSPACE},
+				{Code: CONTROL, Rune: 'a'},
+			},
+		},
+		{
+			description: "Arrow keys",
+			input:       "\x1b[A\x1b[B\x1b[C\x1b[D\x1bOA",
+			expectedKeys: []Key{
+				{Code: UP},
+				{Code: DOWN},
+				{Code: RIGHT},
+				{Code: LEFT},
+				{Code: UP},
+			},
+		},
+		{
+			description: "Tilde sequences",
+			input:       "\x1b[2~\x1b[3~\x1b[5~\x1b[6~\x1b[1~\x1b[4~",
+			expectedKeys: []Key{
+				{Code: INSERT},
+				{Code: DELETE},
+				{Code: PAGE_UP},
+				{Code: PAGE_DOWN},
+				{Code: HOME},
+				{Code: END},
+			},
+		},
+		{
+			description: "Modified arrow is still an arrow",
+			input:       "\x1b[1;5Cx",
+			expectedKeys: []Key{
+				{Code: RIGHT},
+				RuneKey('x'),
+			},
+		},
+		{
+			description: "Bracketed paste arrives as one key",
+			input:       "a\x1b[200~one\r\ntwo\x1b[Bthree\x1b[201~b",
+			expectedKeys: []Key{
+				RuneKey('a'),
+				{Code: PASTE, Text: "one\r\ntwo\x1b[Bthree"},
+				RuneKey('b'),
+			},
+		},
+		{
+			description:  "Lone escape",
+			input:        "\x1b",
+			expectedKeys: []Key{{Code: ESCAPE}},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			reader := NewStreamReader(strings.NewReader(trial.input))
+			actualKeys := []Key{}
+			for {
+				key, err := reader.ReadKey()
+				if err == io.EOF {
+					break
+				}
+				assert.Nil(tt, err)
+				actualKeys = append(actualKeys, key)
+			}
+			assert.Equal(tt, trial.expectedKeys, actualKeys)
+		})
+	}
+}
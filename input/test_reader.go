@@ -0,0 +1,26 @@
+package input
+
+import "io"
+
+// TestReader replays a fixed list of keys, returning io.EOF once they run out
+type TestReader struct {
+	Keys []Key
+}
+
+func (reader *TestReader) ReadKey() (Key, error) {
+	if len(reader.Keys) == 0 {
+		return Key{}, io.EOF
+	}
+	key := reader.Keys[0]
+	reader.Keys = reader.Keys[1:]
+	return key, nil
+}
+
+// Keys for each character of a string, for typing text in tests
+func RuneKeys(input string) []Key {
+	output := []Key{}
+	for _, character := range input {
+		output = append(output, RuneKey(character))
+	}
+	return output
+}
@@ -0,0 +1,200 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+const checkedBox = "[x] "
+const uncheckedBox = "[ ] "
+
+// Checklist asks the user to pick any number of options.  Space toggles the current
+// option, a toggles every option, and enter confirms.  Every move and toggle is
+// announced along with whether the option is checked, and lists longer than the window
+// are shown a page at a time.
+type Checklist struct {
+	terminal *terminal.Terminal
+	reader   input.KeyReader
+	buffer   buffer.Buffer
+
+	label    string
+	options  []string
+	checked  []bool
+	selected int
+}
+
+func NewChecklist(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	options []string,
+) Checklist {
+	return Checklist{
+		terminal: term,
+		reader:   reader,
+		buffer:   buffer.NewBuffer(),
+		label:    label,
+		options:  options,
+		checked:  make([]bool, len(options)),
+	}
+}
+
+// Sets whether an option starts checked
+func (checklist *Checklist) SetChecked(index int, checked bool) *Checklist {
+	if index >= 0 && index < len(checklist.options) {
+		checklist.checked[index] = checked
+	}
+	return checklist
+}
+
+// Shows the checklist and waits for it to be confirmed, returning the indices and text
+// of the checked options.  Escape cancels with ErrCancelled.
+func (checklist *Checklist) Run() ([]int, []string, error) {
+	if len(checklist.options) == 0 {
+		return nil, nil, fmt.Errorf("no options to check")
+	}
+	err := withBuffer(checklist.terminal, &checklist.buffer, func() error {
+		checklist.draw()
+		checklist.announceItem()
+		for {
+			key, err := checklist.reader.ReadKey()
+			if err != nil {
+				return err
+			}
+			switch {
+			case key.Code == input.UP:
+				checklist.moveTo(checklist.selected - 1)
+			case key.Code == input.DOWN:
+				checklist.moveTo(checklist.selected + 1)
+			case key.Code == input.HOME:
+				checklist.moveTo(0)
+			case key.Code == input.END:
+				checklist.moveTo(len(checklist.options) - 1)
+			case key.Code == input.PAGE_UP:
+				checklist.moveTo(checklist.selected - pageSize(checklist.terminal))
+			case key.Code == input.PAGE_DOWN:
+				checklist.moveTo(checklist.selected + pageSize(checklist.terminal))
+			case key.Code == input.RUNE && key.Rune == ' ':
+				checklist.toggle()
+			case key.Code == input.RUNE && key.Rune == 'a':
+				checklist.toggleAll()
+			case key.Code == input.ENTER:
+				_, values := checklist.results()
+				answer := strings.Join(values, ", ")
+				if answer == "" {
+					answer = "none"
+				}
+				finish(checklist.terminal, checklist.label, answer)
+				return nil
+			case key.Code == input.ESCAPE:
+				finish(checklist.terminal, checklist.label, "cancelled")
+				return ErrCancelled
+			}
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	indices, values := checklist.results()
+	return indices, values, nil
+}
+
+func (checklist Checklist) results() ([]int, []string) {
+	indices := []int{}
+	values := []string{}
+	for i, checked := range checklist.checked {
+		if checked {
+			indices = append(indices, i)
+			values = append(values, checklist.options[i])
+		}
+	}
+	return indices, values
+}
+
+func (checklist *Checklist) moveTo(index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(checklist.options) {
+		index = len(checklist.options) - 1
+	}
+	if index == checklist.selected {
+		return
+	}
+	checklist.selected = index
+	checklist.draw()
+	checklist.announceItem()
+}
+
+func (checklist *Checklist) toggle() {
+	checklist.checked[checklist.selected] = !checklist.checked[checklist.selected]
+	checklist.draw()
+	checklist.terminal.Announce(fmt.Sprintf(
+		"%v: %v",
+		checkedState(checklist.checked[checklist.selected]),
+		checklist.options[checklist.selected],
+	))
+}
+
+// Checks every option, or unchecks them all if they are already all checked
+func (checklist *Checklist) toggleAll() {
+	allChecked := true
+	for _, checked := range checklist.checked {
+		allChecked = allChecked && checked
+	}
+	for i := range checklist.checked {
+		checklist.checked[i] = !allChecked
+	}
+	checklist.draw()
+	checklist.terminal.Announce(fmt.Sprintf("all %v", checkedState(!allChecked)))
+}
+
+func checkedState(checked bool) string {
+	if checked {
+		return "checked"
+	}
+	return "not checked"
+}
+
+func (checklist *Checklist) draw() {
+	start, end := pageBounds(
+		checklist.selected,
+		len(checklist.options),
+		pageSize(checklist.terminal),
+	)
+	lines := []string{}
+	for i := start; i < end; i++ {
+		line := unselectedMarker
+		if i == checklist.selected {
+			line = selectMarker
+		}
+		if checklist.checked[i] {
+			line += checkedBox
+		} else {
+			line += uncheckedBox
+		}
+		lines = append(lines, line+checklist.options[i])
+	}
+	renderLines(
+		&checklist.buffer,
+		checklist.label,
+		lines,
+		checklist.selected-start,
+		len(selectMarker),
+	)
+	checklist.terminal.Draw()
+}
+
+func (checklist *Checklist) announceItem() {
+	checklist.terminal.Announce(fmt.Sprintf(
+		"item %v of %v: %v, %v",
+		checklist.selected+1,
+		len(checklist.options),
+		checklist.options[checklist.selected],
+		checkedState(checklist.checked[checklist.selected]),
+	))
+}
@@ -0,0 +1,105 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklist(t *testing.T) {
+	options := []string{"apple", "banana", "cherry"}
+	trials := []struct {
+		description           string
+		keys                  []input.Key
+		expectedIndices       []int
+		expectedValues        []string
+		expectedAnnouncements []string
+	}{
+		{
+			description:     "Confirm with nothing checked",
+			keys:            []input.Key{{Code: input.ENTER}},
+			expectedIndices: []int{},
+			expectedValues:  []string{},
+			expectedAnnouncements: []string{
+				"item 1 of 3: apple, not checked",
+			},
+		},
+		{
+			description: "Toggle and move",
+			keys: []input.Key{
+				input.RuneKey(' '),
+				{Code: input.DOWN},
+				{Code: input.DOWN},
+				input.RuneKey(' '),
+				input.RuneKey(' '),
+				input.RuneKey(' '),
+				{Code: input.ENTER},
+			},
+			expectedIndices: []int{0, 2},
+			expectedValues:  []string{"apple", "cherry"},
+			expectedAnnouncements: []string{
+				"item 1 of 3: apple, not checked",
+				"checked: apple",
+				"item 2 of 3: banana, not checked",
+				"item 3 of 3: cherry, not checked",
+				"checked: cherry",
+				"not checked: cherry",
+				"checked: cherry",
+			},
+		},
+		{
+			description: "Toggle all twice after checking one",
+			keys: []input.Key{
+				input.RuneKey(' '),
+				input.RuneKey('a'),
+				input.RuneKey('a'),
+				{Code: input.ENTER},
+			},
+			expectedIndices: []int{},
+			expectedValues:  []string{},
+			expectedAnnouncements: []string{
+				"item 1 of 3: apple, not checked",
+				"checked: apple",
+				"all checked",
+				"all not checked",
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			term, _, announcer := newTestTerminal(20)
+			reader := input.TestReader{Keys: trial.keys}
+			checklist := NewChecklist(term, &reader, "Fruit", options)
+
+			actualIndices, actualValues, actualErr := checklist.Run()
+
+			assert.Nil(tt, actualErr)
+			assert.Equal(tt, trial.expectedIndices, actualIndices)
+			assert.Equal(tt, trial.expectedValues, actualValues)
+			assert.Equal(tt, trial.expectedAnnouncements, announcer.Messages)
+		})
+	}
+}
+
+func TestChecklistPaging(t *testing.T) {
+	term, _, _ := newTestTerminal(5)
+	options := []string{"one", "two", "three", "four", "five", "six", "seven"}
+	reader := input.TestReader{}
+	checklist := NewChecklist(term, &reader, "Numbers", options)
+	checklist.SetChecked(4, true)
+
+	checklist.draw()
+	value, _ := checklist.buffer.OutputWithoutPrefix()
+	assert.Equal(t, "> [ ] one\n  [ ] two\n  [ ] three", value)
+
+	checklist.moveTo(4)
+	value, position := checklist.buffer.OutputWithoutPrefix()
+	assert.Equal(t, "  [ ] four\n> [x] five\n  [ ] six", value)
+	assert.Equal(t, 13, position)
+
+	checklist.moveTo(6)
+	value, _ = checklist.buffer.OutputWithoutPrefix()
+	assert.Equal(t, "> [ ] seven", value)
+}
@@ -0,0 +1,63 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+// Confirm asks a yes or no question, with an empty answer taking the default
+type Confirm struct {
+	input         Input
+	defaultAnswer bool
+}
+
+func NewConfirm(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	defaultAnswer bool,
+) Confirm {
+	if defaultAnswer {
+		label += " (Y/n)"
+	} else {
+		label += " (y/N)"
+	}
+	return Confirm{
+		input:         NewInput(term, reader, label),
+		defaultAnswer: defaultAnswer,
+	}
+}
+
+func parseYesNo(value string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	}
+	return false, false
+}
+
+func (confirm *Confirm) Run() (bool, error) {
+	confirm.input.SetValidator(func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		if _, ok := parseYesNo(value); !ok {
+			return errors.New("please answer yes or no")
+		}
+		return nil
+	})
+	answer, err := confirm.input.Run()
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(answer) == "" {
+		return confirm.defaultAnswer, nil
+	}
+	result, _ := parseYesNo(answer)
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// Applies a basic line editing key to a buffer, returning false if the key is not an
+// editing key.  Text the buffer's constraints reject, and moves past either end of
+// the buffer, are reported through term.
+func editLine(term *terminal.Terminal, buf *buffer.Buffer, key input.Key) bool {
+	value, position := buf.OutputWithoutPrefix()
+	switch key.Code {
+	case input.RUNE:
+		if !buf.AddCharacter(key.Rune) {
+			term.RejectInput()
+		}
+	case input.PASTE:
+		// Prompts take a single line, so pasted line breaks become spaces
+		if !buf.AddString(lineBreaks.Replace(key.Text)) {
+			term.RejectInput()
+		}
+	case input.BACKSPACE, input.LEFT:
+		if position == 0 {
+			term.Cue(utils.BUFFER_START)
+		} else if key.Code == input.BACKSPACE {
+			buf.RemoveCharacter()
+		} else {
+			buf.RetreatCursor(1)
+		}
+	case input.DELETE, input.RIGHT:
+		if position == len(value) {
+			term.Cue(utils.BUFFER_END)
+		} else if key.Code == input.DELETE {
+			buf.AdvanceCursor(1)
+			buf.RemoveCharacter()
+		} else {
+			buf.AdvanceCursor(1)
+		}
+	case input.HOME:
+		buf.CursorToLineStart()
+	case input.END:
+		buf.CursorToLineEnd()
+	default:
+		return false
+	}
+	return true
+}
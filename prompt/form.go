@@ -0,0 +1,346 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+type FieldKind int
+
+const (
+	TEXT_FIELD     FieldKind = 0
+	PASSWORD_FIELD FieldKind = 1
+	CHOICE_FIELD   FieldKind = 2
+	CHECKBOX_FIELD FieldKind = 3
+)
+
+type field struct {
+	kind      FieldKind
+	label     string
+	buffer    buffer.Buffer
+	validator Validator
+
+	options  []string
+	selected int
+	checked  bool
+}
+
+// Form gathers several labelled fields, each with its own buffer, shown one at a time
+// on a single line.  Tab and shift tab move between fields, announcing the label and
+// current value of the field focused, and enter on the last field shows a review of
+// every answer before asking to submit.
+type Form struct {
+	terminal *terminal.Terminal
+	reader   input.KeyReader
+	fields   []*field
+	focused  int
+	review   buffer.Buffer
+}
+
+func NewForm(term *terminal.Terminal, reader input.KeyReader) Form {
+	return Form{
+		terminal: term,
+		reader:   reader,
+		fields:   []*field{},
+		review:   buffer.NewBuffer(),
+	}
+}
+
+func (form *Form) addField(newField *field) *Form {
+	newField.buffer.SetPrefix(newField.label + ": ")
+	form.fields = append(form.fields, newField)
+	return form
+}
+
+func (form *Form) AddText(label string, initialValue string, validator Validator) *Form {
+	return form.addField(&field{
+		kind:      TEXT_FIELD,
+		label:     label,
+		buffer:    buffer.NewBufferWithString(initialValue),
+		validator: validator,
+	})
+}
+
+func (form *Form) AddPassword(label string, mask rune) *Form {
+	newField := &field{
+		kind:   PASSWORD_FIELD,
+		label:  label,
+		buffer: buffer.NewBuffer(),
+	}
+	newField.buffer.SetMask(mask)
+	return form.addField(newField)
+}
+
+func (form *Form) AddChoice(label string, options []string) *Form {
+	newField := &field{
+		kind:    CHOICE_FIELD,
+		label:   label,
+		buffer:  buffer.NewBuffer(),
+		options: options,
+	}
+	newField.render()
+	return form.addField(newField)
+}
+
+func (form *Form) AddCheckbox(label string, checked bool) *Form {
+	newField := &field{
+		kind:    CHECKBOX_FIELD,
+		label:   label,
+		buffer:  buffer.NewBuffer(),
+		checked: checked,
+	}
+	newField.render()
+	return form.addField(newField)
+}
+
+func (form Form) find(label string) *field {
+	for _, existing := range form.fields {
+		if existing.label == label {
+			return existing
+		}
+	}
+	return nil
+}
+
+// The answer to a text field
+func (form Form) Text(label string) string {
+	if found := form.find(label); found != nil && found.kind == TEXT_FIELD {
+		value, _ := found.buffer.OutputWithoutPrefix()
+		return value
+	}
+	return ""
+}
+
+// The answer to a password field.  The caller should zero the returned bytes once it
+// is finished with them.
+func (form Form) Secret(label string) []byte {
+	if found := form.find(label); found != nil && found.kind == PASSWORD_FIELD {
+		return found.buffer.Secret()
+	}
+	return nil
+}
+
+// The chosen option of a choice field
+func (form Form) Choice(label string) string {
+	if found := form.find(label); found != nil && found.kind == CHOICE_FIELD &&
+		len(found.options) > 0 {
+		return found.options[found.selected]
+	}
+	return ""
+}
+
+func (form Form) Checked(label string) bool {
+	if found := form.find(label); found != nil && found.kind == CHECKBOX_FIELD {
+		return found.checked
+	}
+	return false
+}
+
+// Zeroes the answers to any password fields
+func (form *Form) Clear() {
+	for _, existing := range form.fields {
+		if existing.kind == PASSWORD_FIELD {
+			existing.buffer.Clear()
+		}
+	}
+}
+
+// Shows the form until it is submitted from the review step.  Escape cancels with
+// ErrCancelled.
+func (form *Form) Run() error {
+	if len(form.fields) == 0 {
+		return fmt.Errorf("no fields in form")
+	}
+	return withBuffer(form.terminal, &form.review, func() error {
+		form.focus(0)
+		for {
+			key, err := form.reader.ReadKey()
+			if err != nil {
+				return err
+			}
+			current := form.fields[form.focused]
+			switch {
+			case key.Code == input.TAB:
+				form.focus((form.focused + 1) % len(form.fields))
+			case key.Code == input.SHIFT_TAB:
+				form.focus((form.focused + len(form.fields) - 1) % len(form.fields))
+			case key.Code == input.ENTER && form.focused < len(form.fields)-1:
+				form.focus(form.focused + 1)
+			case key.Code == input.ENTER:
+				submitted, err := form.submit()
+				if submitted || err != nil {
+					return err
+				}
+			case key.Code == input.ESCAPE:
+				form.terminal.ReplaceBuffer(&form.review)
+				finish(form.terminal, "Form", "cancelled")
+				return ErrCancelled
+			case current.handleKey(form.terminal, key):
+				form.terminal.Draw()
+			}
+		}
+	})
+}
+
+func (form *Form) focus(index int) {
+	form.focused = index
+	focused := form.fields[index]
+	form.terminal.ReplaceBuffer(&focused.buffer)
+	form.terminal.Announce(fmt.Sprintf(
+		"%v, %v, %v",
+		focused.label,
+		focused.kindDescription(),
+		focused.spokenValue(),
+	))
+}
+
+// Validates every field, then shows the answers and asks for confirmation.  Returns
+// true if the form was submitted.
+func (form *Form) submit() (bool, error) {
+	for i, existing := range form.fields {
+		if existing.validator == nil {
+			continue
+		}
+		value, _ := existing.buffer.OutputWithoutPrefix()
+		if err := existing.validator(value); err != nil {
+			form.focus(i)
+			message := fmt.Sprintf("%v: %v", existing.label, err.Error())
+			form.terminal.PrintLines(message)
+			form.terminal.Announce(message)
+			return false, nil
+		}
+	}
+
+	lines := []string{}
+	for _, existing := range form.fields {
+		lines = append(lines, fmt.Sprintf("%v: %v", existing.label, existing.spokenValue()))
+	}
+	form.terminal.ReplaceBuffer(&form.review)
+	form.review.SetPrefix("Review\n").SetString(strings.Join(lines, "\n"))
+	form.terminal.Draw()
+	form.terminal.EndLine()
+
+	confirm := NewConfirm(form.terminal, form.reader, "Submit", true)
+	submitted, err := confirm.Run()
+	if err != nil || submitted {
+		return submitted, err
+	}
+	form.focus(0)
+	return false, nil
+}
+
+// Applies a key to the field, returning false if the key means nothing to it
+func (current *field) handleKey(term *terminal.Terminal, key input.Key) bool {
+	switch current.kind {
+	case TEXT_FIELD, PASSWORD_FIELD:
+		if key.Code == input.INSERT {
+			term.ToggleOverwrite()
+			return false
+		}
+		return editLine(term, &current.buffer, key)
+	case CHOICE_FIELD:
+		if len(current.options) == 0 {
+			return false
+		}
+		switch key.Code {
+		case input.LEFT, input.UP:
+			current.selected = (current.selected + len(current.options) - 1) %
+				len(current.options)
+		case input.RIGHT, input.DOWN:
+			current.selected = (current.selected + 1) % len(current.options)
+		case input.RUNE:
+			if !current.jumpTo(key.Rune) {
+				return false
+			}
+		default:
+			return false
+		}
+	case CHECKBOX_FIELD:
+		if key.Code != input.RUNE || key.Rune != ' ' {
+			return false
+		}
+		current.checked = !current.checked
+	}
+	current.render()
+	term.Announce(current.spokenValue())
+	return true
+}
+
+func (current *field) jumpTo(letter rune) bool {
+	letter = unicode.ToLower(letter)
+	for i := 1; i <= len(current.options); i++ {
+		index := (current.selected + i) % len(current.options)
+		for _, first := range current.options[index] {
+			if unicode.ToLower(first) == letter {
+				current.selected = index
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// Sets the buffer of a choice or checkbox field to show its value
+func (current *field) render() {
+	switch current.kind {
+	case CHOICE_FIELD:
+		if len(current.options) > 0 {
+			current.buffer.SetString(current.options[current.selected])
+		}
+	case CHECKBOX_FIELD:
+		if current.checked {
+			current.buffer.SetString(checkedBox)
+		} else {
+			current.buffer.SetString(uncheckedBox)
+		}
+		current.buffer.SetCursor(1)
+	}
+}
+
+func (current field) kindDescription() string {
+	switch current.kind {
+	case PASSWORD_FIELD:
+		return "password field"
+	case CHOICE_FIELD:
+		return fmt.Sprintf("choice of %v", len(current.options))
+	case CHECKBOX_FIELD:
+		return "checkbox"
+	}
+	return "text field"
+}
+
+// The value of the field as it should be read out, never revealing a password
+func (current field) spokenValue() string {
+	switch current.kind {
+	case PASSWORD_FIELD:
+		// The value of a masked buffer holds a placeholder for each character
+		placeholders, _ := current.buffer.OutputWithoutPrefix()
+		if placeholders == "" {
+			return "empty"
+		}
+		return fmt.Sprintf("%v characters", len(placeholders))
+	case CHOICE_FIELD:
+		if len(current.options) == 0 {
+			return "no options"
+		}
+		return fmt.Sprintf(
+			"%v, %v of %v",
+			current.options[current.selected],
+			current.selected+1,
+			len(current.options),
+		)
+	case CHECKBOX_FIELD:
+		return checkedState(current.checked)
+	}
+	value, _ := current.buffer.OutputWithoutPrefix()
+	if value == "" {
+		return "empty"
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestForm(reader input.KeyReader) (Form, func() []string) {
+	term, _, announcer := newTestTerminal(20)
+	form := NewForm(term, reader)
+	form.AddText("Name", "", func(value string) error {
+		if value == "" {
+			return errors.New("name is required")
+		}
+		return nil
+	})
+	form.AddPassword("Password", '*')
+	form.AddChoice("Colour", []string{"red", "green", "blue"})
+	form.AddCheckbox("Subscribe", false)
+	return form, func() []string { return announcer.Messages }
+}
+
+func TestForm(t *testing.T) {
+	reader := input.TestReader{Keys: keys(
+		"Ada", input.TAB,
+		"pw", input.TAB,
+		input.RIGHT, "b", input.TAB,
+		" ", input.ENTER,
+		input.ENTER,
+	)}
+	form, announcements := newTestForm(&reader)
+
+	err := form.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Ada", form.Text("Name"))
+	assert.Equal(t, "pw", string(form.Secret("Password")))
+	assert.Equal(t, "blue", form.Choice("Colour"))
+	assert.True(t, form.Checked("Subscribe"))
+	assert.Equal(t, []string{
+		"Name, text field, empty",
+		"Password, password field, empty",
+		"Colour, choice of 3, red, 1 of 3",
+		"green, 2 of 3",
+		"blue, 3 of 3",
+		"Subscribe, checkbox, not checked",
+		"checked",
+	}, announcements())
+
+	form.Clear()
+	assert.Equal(t, "", string(form.Secret("Password")))
+}
+
+func TestFormValidationAndReview(t *testing.T) {
+	reader := input.TestReader{Keys: keys(
+		input.SHIFT_TAB, input.ENTER,
+		"Ada", input.SHIFT_TAB, input.ENTER,
+		"n", input.ENTER,
+		input.ESCAPE,
+	)}
+	form, announcements := newTestForm(&reader)
+
+	err := form.Run()
+
+	assert.Equal(t, ErrCancelled, err)
+	assert.Equal(t, []string{
+		"Name, text field, empty",
+		"Subscribe, checkbox, not checked",
+		"Name, text field, empty",
+		"Name: name is required",
+		"Subscribe, checkbox, not checked",
+		"Name, text field, Ada",
+	}, announcements())
+}
@@ -0,0 +1,117 @@
+package prompt
+
+import (
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+// Validator checks an answer, returning an error describing the problem to the user if
+// it is not acceptable
+type Validator func(value string) error
+
+// Input asks the user for a line of free text.  If the answer fails validation the
+// error is printed on its own line below the input and announced, and the answer is
+// kept so it can be corrected rather than typed again.
+type Input struct {
+	terminal  *terminal.Terminal
+	reader    input.KeyReader
+	buffer    buffer.Buffer
+	label     string
+	validator Validator
+}
+
+func NewInput(term *terminal.Terminal, reader input.KeyReader, label string) Input {
+	return Input{
+		terminal: term,
+		reader:   reader,
+		buffer:   buffer.NewBuffer(),
+		label:    label,
+	}
+}
+
+func (prompt *Input) SetValidator(validator Validator) *Input {
+	prompt.validator = validator
+	return prompt
+}
+
+// Sets the text the answer starts with
+func (prompt *Input) SetInitialValue(value string) *Input {
+	prompt.buffer.SetString(value)
+	return prompt
+}
+
+// Limits the answer to length characters, for fields such as port numbers
+func (prompt *Input) SetMaxLength(length int) *Input {
+	prompt.buffer.SetMaxLength(length)
+	return prompt
+}
+
+// Only accepts the characters in allowed, rejecting any others as they are typed
+func (prompt *Input) SetAllowed(allowed string) *Input {
+	prompt.buffer.SetAllowed(allowed)
+	return prompt
+}
+
+// Only accepts characters for which filter returns true
+func (prompt *Input) SetFilter(filter func(rune) bool) *Input {
+	prompt.buffer.SetFilter(filter)
+	return prompt
+}
+
+// Changes each character as it is typed, such as unicode.ToUpper
+func (prompt *Input) SetTransform(transform func(rune) rune) *Input {
+	prompt.buffer.SetTransform(transform)
+	return prompt
+}
+
+// Shows the prompt and waits for a valid answer.  Escape cancels with ErrCancelled.
+func (prompt *Input) Run() (string, error) {
+	answer := ""
+	err := prompt.read(func() bool {
+		answer, _ = prompt.buffer.OutputWithoutPrefix()
+		return prompt.validate(answer)
+	})
+	return answer, err
+}
+
+// Edits the answer until enter is pressed and accept agrees the answer can be taken
+func (prompt *Input) read(accept func() bool) error {
+	prompt.buffer.SetPrefix(prompt.label + ": ")
+	return withBuffer(prompt.terminal, &prompt.buffer, func() error {
+		prompt.terminal.Draw()
+		for {
+			key, err := prompt.reader.ReadKey()
+			if err != nil {
+				return err
+			}
+			switch {
+			case key.Code == input.ENTER:
+				if accept() {
+					prompt.terminal.EndLine()
+					return nil
+				}
+			case key.Code == input.ESCAPE:
+				prompt.terminal.EndLine()
+				return ErrCancelled
+			case key.Code == input.INSERT:
+				prompt.terminal.ToggleOverwrite()
+			case editLine(prompt.terminal, &prompt.buffer, key):
+				prompt.terminal.Draw()
+			}
+		}
+	})
+}
+
+func (prompt *Input) validate(answer string) bool {
+	if prompt.validator == nil {
+		return true
+	}
+	err := prompt.validator(answer)
+	if err == nil {
+		return true
+	}
+	prompt.terminal.PrintLines(err.Error())
+	prompt.terminal.Announce(err.Error())
+	return false
+}
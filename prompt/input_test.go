@@ -0,0 +1,261 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/bekreth/screen_reader_terminal/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func keys(inputs ...any) []input.Key {
+	output := []input.Key{}
+	for _, value := range inputs {
+		switch v := value.(type) {
+		case string:
+			output = append(output, input.RuneKeys(v)...)
+		case input.KeyCode:
+			output = append(output, input.Key{Code: v})
+		case input.Key:
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+func TestInputKeepsAnswerAfterValidationError(t *testing.T) {
+	term, written, announcer := newTestTerminal(20)
+	reader := input.TestReader{Keys: keys("helo", input.ENTER, input.LEFT, "l", input.ENTER)}
+	prompt := NewInput(term, &reader, "Greeting")
+	prompt.SetValidator(func(value string) error {
+		if value != "hello" {
+			return errors.New("say hello")
+		}
+		return nil
+	})
+
+	answer, err := prompt.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", answer)
+	assert.Equal(t, []string{"say hello"}, announcer.Messages)
+	assert.Contains(t, written.String(), "\nsay hello\nGreeting: helo")
+}
+
+func TestInputPasteJoinsLines(t *testing.T) {
+	term, _, _ := newTestTerminal(20)
+	paste := input.Key{Code: input.PASTE, Text: "one\r\ntwo\nthree"}
+	reader := input.TestReader{Keys: keys("a ", paste, input.ENTER)}
+
+	prompt := NewInput(term, &reader, "Words")
+
+	answer, err := prompt.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "a one two three", answer)
+}
+
+func TestInputOverwrite(t *testing.T) {
+	term, _, announcer := newTestTerminal(20)
+	reader := input.TestReader{Keys: keys(input.HOME, input.INSERT, "J", input.ENTER)}
+	prompt := NewInput(term, &reader, "Name")
+	prompt.SetInitialValue("jo")
+
+	answer, err := prompt.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jo", answer)
+	assert.Equal(t, []string{"overwrite"}, announcer.Messages)
+}
+
+func TestInputConstraints(t *testing.T) {
+	trials := []struct {
+		description           string
+		speakRejections       bool
+		expectedAnnouncements []string
+		expectedBell          bool
+	}{
+		{
+			description:  "Rejected keys ring the bell",
+			expectedBell: true,
+		},
+		{
+			description:           "Rejected keys are spoken",
+			speakRejections:       true,
+			expectedAnnouncements: []string{"not allowed", "not allowed"},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			term, written, announcer := newTestTerminal(20)
+			term.SetSettings(terminal.Settings{SpeakRejections: trial.speakRejections})
+			reader := input.TestReader{Keys: keys("a1fg2", input.ENTER)}
+			prompt := NewInput(term, &reader, "Key")
+			prompt.SetAllowed("0123456789ABCDEF").SetTransform(unicode.ToUpper).SetMaxLength(3)
+
+			answer, err := prompt.Run()
+
+			assert.Nil(tt, err)
+			assert.Equal(tt, "A1F", answer)
+			assert.Equal(tt, trial.expectedAnnouncements, announcer.Messages)
+			assert.Equal(tt, trial.expectedBell, strings.Contains(written.String(), "\a"))
+		})
+	}
+}
+
+func TestInputCuesAtBufferEnds(t *testing.T) {
+	term, _, _ := newTestTerminal(20)
+	player := utils.TestCuePlayer{}
+	term.SetCuePlayer(&player)
+	reader := input.TestReader{Keys: keys(
+		input.BACKSPACE, "ab", input.RIGHT, input.DELETE,
+		input.HOME, input.LEFT, input.RIGHT, input.ENTER,
+	)}
+	prompt := NewInput(term, &reader, "Name")
+
+	answer, err := prompt.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ab", answer)
+	assert.Equal(t, []utils.Cue{
+		utils.BUFFER_START, utils.BUFFER_END, utils.BUFFER_END, utils.BUFFER_START,
+	}, player.Cues)
+}
+
+func TestConfirm(t *testing.T) {
+	trials := []struct {
+		description           string
+		keys                  []input.Key
+		defaultAnswer         bool
+		expectedAnswer        bool
+		expectedAnnouncements []string
+	}{
+		{
+			description:    "Empty answer takes default yes",
+			keys:           keys(input.ENTER),
+			defaultAnswer:  true,
+			expectedAnswer: true,
+		},
+		{
+			description:    "Empty answer takes default no",
+			keys:           keys(input.ENTER),
+			defaultAnswer:  false,
+			expectedAnswer: false,
+		},
+		{
+			description:    "Answer yes",
+			keys:           keys("Yes", input.ENTER),
+			defaultAnswer:  false,
+			expectedAnswer: true,
+		},
+		{
+			description: "Unclear answer is rejected",
+			keys: keys(
+				"maybe", input.ENTER,
+				input.HOME, input.DELETE, input.DELETE, input.DELETE, input.DELETE,
+				input.END, input.BACKSPACE, "n", input.ENTER,
+			),
+			defaultAnswer:         true,
+			expectedAnswer:        false,
+			expectedAnnouncements: []string{"please answer yes or no"},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			term, _, announcer := newTestTerminal(20)
+			reader := input.TestReader{Keys: trial.keys}
+			confirm := NewConfirm(term, &reader, "Continue?", trial.defaultAnswer)
+
+			actualAnswer, actualErr := confirm.Run()
+
+			assert.Nil(tt, actualErr)
+			assert.Equal(tt, trial.expectedAnswer, actualAnswer)
+			assert.Equal(tt, trial.expectedAnnouncements, announcer.Messages)
+		})
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	term, _, announcer := newTestTerminal(20)
+	reader := input.TestReader{Keys: keys(
+		"x", input.ENTER,
+		input.BACKSPACE, "12", input.ENTER,
+		input.BACKSPACE, input.ENTER,
+	)}
+	integer := NewInteger(term, &reader, "Port", 1, 10)
+
+	number, err := integer.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, number)
+	assert.Equal(t, []string{
+		"\"x\" is not a whole number",
+		"12 is not between 1 and 10",
+	}, announcer.Messages)
+
+	reader.Keys = keys("0.5", input.ENTER)
+	float := NewFloat(term, &reader, "Ratio", 0, 1)
+	ratio, err := float.Run()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, ratio)
+
+	reader.Keys = keys(input.ESCAPE)
+	float = NewFloat(term, &reader, "Ratio", 0, 1)
+	_, err = float.Run()
+	assert.Equal(t, ErrCancelled, err)
+}
+
+func TestFloatRejectsNonNumbers(t *testing.T) {
+	term, _, announcer := newTestTerminal(20)
+	reader := input.TestReader{Keys: keys(
+		"NaN", input.ENTER,
+		input.BACKSPACE, input.BACKSPACE, input.BACKSPACE, "Inf", input.ENTER,
+		input.BACKSPACE, input.BACKSPACE, input.BACKSPACE, "5", input.ENTER,
+	)}
+	float := NewFloat(term, &reader, "Ratio", 0, 10)
+
+	number, err := float.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, number)
+	assert.Equal(t, []string{
+		"\"NaN\" is not a number",
+		"\"Inf\" is not a number",
+	}, announcer.Messages)
+}
+
+func TestPassword(t *testing.T) {
+	term, written, _ := newTestTerminal(20)
+	reader := input.TestReader{Keys: keys("hunter", input.BACKSPACE, "r2", input.ENTER)}
+	prompt := NewPassword(term, &reader, "Password", '*')
+
+	secret, err := prompt.Run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", string(secret))
+	assert.NotContains(t, written.String(), "hunt")
+}
+
+func TestPromptFromDrawnBuffer(t *testing.T) {
+	term, written, _ := newTestTerminal(20)
+	term.CurrentBuffer().SetPrefix("$ ")
+	term.Draw()
+	written.Reset()
+	reader := input.TestReader{Keys: keys("y", input.ENTER)}
+	confirm := NewConfirm(term, &reader, "ok", true)
+
+	confirm.Run()
+
+	// The prompt is drawn over the erased buffer, which is drawn again beneath it
+	assert.Equal(
+		t,
+		window.CSI+"2D"+window.CSI+"0J"+"ok (Y/n): y\n"+"$ ",
+		written.String(),
+	)
+}
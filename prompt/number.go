@@ -0,0 +1,89 @@
+package prompt
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+// Integer asks for a whole number between min and max inclusive
+type Integer struct {
+	input Input
+	min   int
+	max   int
+}
+
+func NewInteger(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	min int,
+	max int,
+) Integer {
+	return Integer{
+		input: NewInput(term, reader, label),
+		min:   min,
+		max:   max,
+	}
+}
+
+func (prompt *Integer) Run() (int, error) {
+	prompt.input.SetValidator(func(value string) error {
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		if number < prompt.min || number > prompt.max {
+			return fmt.Errorf("%v is not between %v and %v", number, prompt.min, prompt.max)
+		}
+		return nil
+	})
+	answer, err := prompt.input.Run()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(answer))
+}
+
+// Float asks for a number between min and max inclusive
+type Float struct {
+	input Input
+	min   float64
+	max   float64
+}
+
+func NewFloat(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	min float64,
+	max float64,
+) Float {
+	return Float{
+		input: NewInput(term, reader, label),
+		min:   min,
+		max:   max,
+	}
+}
+
+func (prompt *Float) Run() (float64, error) {
+	prompt.input.SetValidator(func(value string) error {
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		if number < prompt.min || number > prompt.max {
+			return fmt.Errorf("%v is not between %v and %v", number, prompt.min, prompt.max)
+		}
+		return nil
+	})
+	answer, err := prompt.input.Run()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(answer), 64)
+}
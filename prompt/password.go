@@ -0,0 +1,34 @@
+package prompt
+
+import (
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+// Password asks for a secret, drawing mask for each character or nothing if it is 0
+type Password struct {
+	input Input
+}
+
+func NewPassword(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	mask rune,
+) Password {
+	prompt := Password{
+		input: NewInput(term, reader, label),
+	}
+	prompt.input.buffer.SetMask(mask)
+	return prompt
+}
+
+// Waits for the secret, which the caller should zero when done
+func (prompt *Password) Run() ([]byte, error) {
+	var secret []byte
+	err := prompt.input.read(func() bool {
+		secret = prompt.input.buffer.Secret()
+		return true
+	})
+	return secret, err
+}
@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+var ErrCancelled = errors.New("prompt cancelled")
+
+// Runs a prompt in its own buffer, drawing the terminal's buffer again afterwards
+func withBuffer(term *terminal.Terminal, buf *buffer.Buffer, run func() error) error {
+	previous := term.ReplaceBuffer(buf)
+	defer func() {
+		term.SwapBuffer(previous)
+		previous.ClearPrevious()
+		term.Draw()
+	}()
+	return run()
+}
+
+// Collapses a prompt down to a single line holding its answer, so the scroll back reads
+// as "label: answer" rather than the whole list that was on screen
+func finish(term *terminal.Terminal, label string, answer string) {
+	term.CurrentBuffer().
+		SetPrefix(label + ": ").
+		SetString(answer)
+	term.Draw()
+	term.EndLine()
+}
+
+// How many items of a list fit on screen below the label, leaving the last row free
+func pageSize(term *terminal.Terminal) int {
+	return utils.IntMax(term.GetWindowSize().Height-2, 1)
+}
+
+// The range of items to show so that selected is on screen.  Lists are split into
+// fixed pages rather than scrolled a line at a time so the lines stay put while the
+// user moves within a page.
+func pageBounds(selected int, count int, size int) (int, int) {
+	start := (selected / size) * size
+	return start, utils.IntMin(start+size, count)
+}
+
+// Lays out lines below a label, with the cursor placed at the start of the selected
+// line's text so screen magnifiers and review cursors follow the selection
+func renderLines(
+	buf *buffer.Buffer,
+	label string,
+	lines []string,
+	selected int,
+	markerWidth int,
+) {
+	cursor := 0
+	for i := 0; i < selected && i < len(lines); i++ {
+		cursor += len(lines[i]) + 1
+	}
+	buf.SetPrefix(label + "\n").
+		SetString(strings.Join(lines, "\n")).
+		SetCursor(cursor + markerWidth)
+}
@@ -0,0 +1,137 @@
+package prompt
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+)
+
+const selectMarker = "> "
+const unselectedMarker = "  "
+
+// Select asks the user to pick one option from a list.  Options are drawn one per line,
+// the arrow keys move the marker, typing a letter jumps to the next option starting
+// with it, and every move is announced as "item 3 of 7: option".
+type Select struct {
+	terminal *terminal.Terminal
+	reader   input.KeyReader
+	buffer   buffer.Buffer
+
+	label    string
+	options  []string
+	selected int
+}
+
+func NewSelect(
+	term *terminal.Terminal,
+	reader input.KeyReader,
+	label string,
+	options []string,
+) Select {
+	return Select{
+		terminal: term,
+		reader:   reader,
+		buffer:   buffer.NewBuffer(),
+		label:    label,
+		options:  options,
+	}
+}
+
+// Sets the option selected when the prompt opens
+func (menu *Select) SetSelected(index int) *Select {
+	if index >= 0 && index < len(menu.options) {
+		menu.selected = index
+	}
+	return menu
+}
+
+// Shows the menu and waits for a choice, returning the index and text of the chosen
+// option.  Escape cancels with ErrCancelled.
+func (menu *Select) Run() (int, string, error) {
+	if len(menu.options) == 0 {
+		return -1, "", fmt.Errorf("no options to select from")
+	}
+	err := withBuffer(menu.terminal, &menu.buffer, func() error {
+		menu.draw()
+		menu.announceItem()
+		for {
+			key, err := menu.reader.ReadKey()
+			if err != nil {
+				return err
+			}
+			switch key.Code {
+			case input.UP:
+				menu.moveTo(menu.selected - 1)
+			case input.DOWN:
+				menu.moveTo(menu.selected + 1)
+			case input.HOME:
+				menu.moveTo(0)
+			case input.END:
+				menu.moveTo(len(menu.options) - 1)
+			case input.RUNE:
+				menu.jumpTo(key.Rune)
+			case input.ENTER:
+				finish(menu.terminal, menu.label, menu.options[menu.selected])
+				return nil
+			case input.ESCAPE:
+				finish(menu.terminal, menu.label, "cancelled")
+				return ErrCancelled
+			}
+		}
+	})
+	if err != nil {
+		return -1, "", err
+	}
+	return menu.selected, menu.options[menu.selected], nil
+}
+
+func (menu *Select) moveTo(index int) {
+	if index < 0 || index >= len(menu.options) || index == menu.selected {
+		return
+	}
+	menu.selected = index
+	menu.draw()
+	menu.announceItem()
+}
+
+// Moves to the next option after the current one starting with the typed letter,
+// wrapping around to the top of the list
+func (menu *Select) jumpTo(letter rune) {
+	letter = unicode.ToLower(letter)
+	for i := 1; i <= len(menu.options); i++ {
+		index := (menu.selected + i) % len(menu.options)
+		for _, first := range menu.options[index] {
+			if unicode.ToLower(first) == letter {
+				menu.moveTo(index)
+				return
+			}
+			break
+		}
+	}
+}
+
+func (menu *Select) draw() {
+	start, end := pageBounds(menu.selected, len(menu.options), pageSize(menu.terminal))
+	lines := []string{}
+	for i := start; i < end; i++ {
+		if i == menu.selected {
+			lines = append(lines, selectMarker+menu.options[i])
+		} else {
+			lines = append(lines, unselectedMarker+menu.options[i])
+		}
+	}
+	renderLines(&menu.buffer, menu.label, lines, menu.selected-start, len(selectMarker))
+	menu.terminal.Draw()
+}
+
+func (menu *Select) announceItem() {
+	menu.terminal.Announce(fmt.Sprintf(
+		"item %v of %v: %v",
+		menu.selected+1,
+		len(menu.options),
+		menu.options[menu.selected],
+	))
+}
@@ -0,0 +1,116 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect(t *testing.T) {
+	options := []string{"apple", "banana", "blueberry", "cherry"}
+	trials := []struct {
+		description           string
+		keys                  []input.Key
+		expectedIndex         int
+		expectedValue         string
+		expectedErr           error
+		expectedAnnouncements []string
+	}{
+		{
+			description:   "Choose the first option",
+			keys:          []input.Key{{Code: input.ENTER}},
+			expectedIndex: 0,
+			expectedValue: "apple",
+			expectedAnnouncements: []string{
+				"item 1 of 4: apple",
+			},
+		},
+		{
+			description: "Arrow down and back up",
+			keys: []input.Key{
+				{Code: input.DOWN},
+				{Code: input.DOWN},
+				{Code: input.UP},
+				{Code: input.ENTER},
+			},
+			expectedIndex: 1,
+			expectedValue: "banana",
+			expectedAnnouncements: []string{
+				"item 1 of 4: apple",
+				"item 2 of 4: banana",
+				"item 3 of 4: blueberry",
+				"item 2 of 4: banana",
+			},
+		},
+		{
+			description: "Moving past the end stays put",
+			keys: []input.Key{
+				{Code: input.END},
+				{Code: input.DOWN},
+				{Code: input.ENTER},
+			},
+			expectedIndex: 3,
+			expectedValue: "cherry",
+			expectedAnnouncements: []string{
+				"item 1 of 4: apple",
+				"item 4 of 4: cherry",
+			},
+		},
+		{
+			description: "Type to jump cycles through matches",
+			keys: []input.Key{
+				input.RuneKey('b'),
+				input.RuneKey('B'),
+				input.RuneKey('b'),
+				{Code: input.ENTER},
+			},
+			expectedIndex: 1,
+			expectedValue: "banana",
+			expectedAnnouncements: []string{
+				"item 1 of 4: apple",
+				"item 2 of 4: banana",
+				"item 3 of 4: blueberry",
+				"item 2 of 4: banana",
+			},
+		},
+		{
+			description:   "Escape cancels",
+			keys:          []input.Key{{Code: input.ESCAPE}},
+			expectedIndex: -1,
+			expectedValue: "",
+			expectedErr:   ErrCancelled,
+			expectedAnnouncements: []string{
+				"item 1 of 4: apple",
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			term, _, announcer := newTestTerminal(20)
+			reader := input.TestReader{Keys: trial.keys}
+			menu := NewSelect(term, &reader, "Pick a fruit", options)
+
+			actualIndex, actualValue, actualErr := menu.Run()
+
+			assert.Equal(tt, trial.expectedIndex, actualIndex)
+			assert.Equal(tt, trial.expectedValue, actualValue)
+			assert.Equal(tt, trial.expectedErr, actualErr)
+			assert.Equal(tt, trial.expectedAnnouncements, announcer.Messages)
+		})
+	}
+}
+
+func TestSelectRestoresBuffer(t *testing.T) {
+	term, written, _ := newTestTerminal(20)
+	original := term.CurrentBuffer()
+	reader := input.TestReader{Keys: []input.Key{{Code: input.DOWN}, {Code: input.ENTER}}}
+	menu := NewSelect(term, &reader, "Pick", []string{"one", "two"})
+
+	menu.Run()
+
+	assert.Equal(t, original, term.CurrentBuffer())
+	assert.Contains(t, written.String(), "Pick")
+	assert.Contains(t, written.String(), ": two")
+}
@@ -0,0 +1,26 @@
+package prompt
+
+import (
+	"bytes"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/terminal"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/bekreth/screen_reader_terminal/window"
+)
+
+func newTestTerminal(height int) (*terminal.Terminal, *bytes.Buffer, *utils.TestAnnouncer) {
+	written := bytes.Buffer{}
+	win := window.NewWindow().
+		SetWriter(&written).
+		SetWindowSize(window.WindowSize{
+			Height: height,
+			Width:  40,
+		})
+	buf := buffer.NewBuffer()
+	announcer := utils.TestAnnouncer{}
+
+	term := terminal.NewTerminal(win, &buf, utils.NoOpLogger{})
+	term.SetAnnouncer(&announcer)
+	return &term, &written, &announcer
+}
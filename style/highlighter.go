@@ -0,0 +1,62 @@
+package style
+
+import "unicode"
+
+// Highlighter styles the text of a buffer as it is typed.  The text of the returned
+// spans must join to form value exactly, or the highlighting is ignored.
+type Highlighter interface {
+	Highlight(value string) []Span
+}
+
+// KeywordHighlighter draws whole words found in its keyword list in a single style
+type KeywordHighlighter struct {
+	keywords map[string]bool
+	style    Style
+}
+
+func NewKeywordHighlighter(style Style, keywords ...string) KeywordHighlighter {
+	highlighter := KeywordHighlighter{
+		keywords: map[string]bool{},
+		style:    style,
+	}
+	for _, keyword := range keywords {
+		highlighter.keywords[keyword] = true
+	}
+	return highlighter
+}
+
+func (highlighter KeywordHighlighter) Highlight(value string) []Span {
+	output := []Span{}
+	plainStart := 0
+	wordStart := -1
+	endWord := func(end int) {
+		if highlighter.keywords[value[wordStart:end]] {
+			if plainStart < wordStart {
+				output = append(output, Plain(value[plainStart:wordStart]))
+			}
+			output = append(output, Span{Text: value[wordStart:end], Style: highlighter.style})
+			plainStart = end
+		}
+		wordStart = -1
+	}
+	for i, character := range value {
+		if isWordCharacter(character) {
+			if wordStart < 0 {
+				wordStart = i
+			}
+		} else if wordStart >= 0 {
+			endWord(i)
+		}
+	}
+	if wordStart >= 0 {
+		endWord(len(value))
+	}
+	if plainStart < len(value) {
+		output = append(output, Plain(value[plainStart:]))
+	}
+	return output
+}
+
+func isWordCharacter(character rune) bool {
+	return unicode.IsLetter(character) || unicode.IsDigit(character) || character == '_'
+}
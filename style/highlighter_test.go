@@ -0,0 +1,49 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeywordHighlighter(t *testing.T) {
+	keyword := Style{Bold: true}
+	trials := []struct {
+		description    string
+		input          string
+		expectedOutput []Span
+	}{
+		{
+			description:    "Empty value",
+			input:          "",
+			expectedOutput: []Span{},
+		},
+		{
+			description:    "No keywords",
+			input:          "print x",
+			expectedOutput: []Span{Plain("print x")},
+		},
+		{
+			description: "Keywords among other words",
+			input:       "if x then y",
+			expectedOutput: []Span{
+				{Text: "if", Style: keyword},
+				Plain(" x "),
+				{Text: "then", Style: keyword},
+				Plain(" y"),
+			},
+		},
+		{
+			description:    "Keywords only match whole words",
+			input:          "iffy thenceforth",
+			expectedOutput: []Span{Plain("iffy thenceforth")},
+		},
+	}
+
+	highlighter := NewKeywordHighlighter(keyword, "if", "then")
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			assert.Equal(tt, trial.expectedOutput, highlighter.Highlight(trial.input))
+		})
+	}
+}
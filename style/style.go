@@ -0,0 +1,145 @@
+package style
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Color is a foreground colour, numbered by its SGR code
+type Color int
+
+const (
+	DEFAULT_COLOR Color = 0
+	BLACK         Color = 30
+	RED           Color = 31
+	GREEN         Color = 32
+	YELLOW        Color = 33
+	BLUE          Color = 34
+	MAGENTA       Color = 35
+	CYAN          Color = 36
+	WHITE         Color = 37
+	BRIGHT_BLACK  Color = 90
+)
+
+const (
+	CSI   = "\x1B["
+	RESET = CSI + "0m"
+)
+
+type Style struct {
+	Bold       bool
+	Faint      bool
+	Underline  bool
+	Foreground Color
+}
+
+// Span is a run of text drawn in a single style
+type Span struct {
+	Text  string
+	Style Style
+}
+
+func Plain(text string) Span {
+	return Span{Text: text}
+}
+
+func Bold(text string) Span {
+	return Span{Text: text, Style: Style{Bold: true}}
+}
+
+func Underline(text string) Span {
+	return Span{Text: text, Style: Style{Underline: true}}
+}
+
+func Colored(text string, color Color) Span {
+	return Span{Text: text, Style: Style{Foreground: color}}
+}
+
+func (style Style) IsPlain() bool {
+	return style == Style{}
+}
+
+// The style with any colour removed, for terminals where NO_COLOR is set
+func (style Style) WithoutColor() Style {
+	style.Foreground = DEFAULT_COLOR
+	return style
+}
+
+// The SGR sequence switching to the style, or nothing for plain text
+func (style Style) Sequence() string {
+	codes := []string{}
+	if style.Bold {
+		codes = append(codes, "1")
+	}
+	if style.Faint {
+		codes = append(codes, "2")
+	}
+	if style.Underline {
+		codes = append(codes, "4")
+	}
+	if style.Foreground != DEFAULT_COLOR {
+		codes = append(codes, fmt.Sprint(int(style.Foreground)))
+	}
+	if len(codes) == 0 {
+		return ""
+	}
+	return CSI + strings.Join(codes, ";") + "m"
+}
+
+// The text of the spans without any styling
+func Text(spans ...Span) string {
+	output := ""
+	for _, span := range spans {
+		output += span.Text
+	}
+	return output
+}
+
+// The style of each byte of the spans' text.  Returns nil if every span is plain.
+func Styles(spans ...Span) []Style {
+	output := []Style{}
+	styled := false
+	for _, span := range spans {
+		styled = styled || !span.Style.IsPlain()
+		for i := 0; i < len(span.Text); i++ {
+			output = append(output, span.Style)
+		}
+	}
+	if !styled {
+		return nil
+	}
+	return output
+}
+
+// Wraps each run of bytes in text in the SGR sequence for its style, and a reset
+func Render(text string, styles []Style, color bool) string {
+	if len(styles) == 0 {
+		return text
+	}
+	builder := strings.Builder{}
+	start := 0
+	for start < len(text) {
+		current := styleAt(styles, start, color)
+		end := start + 1
+		for end < len(text) && styleAt(styles, end, color) == current {
+			end += 1
+		}
+		if current.IsPlain() {
+			builder.WriteString(text[start:end])
+		} else {
+			builder.WriteString(current.Sequence() + text[start:end] + RESET)
+		}
+		start = end
+	}
+	return builder.String()
+}
+
+func styleAt(styles []Style, index int, color bool) Style {
+	if index >= len(styles) {
+		return Style{}
+	}
+	if !color {
+		return styles[index].WithoutColor()
+	}
+	return styles[index]
+}
@@ -0,0 +1,57 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	trials := []struct {
+		description    string
+		spans          []Span
+		color          bool
+		expectedOutput string
+	}{
+		{
+			description:    "Plain spans are left alone",
+			spans:          []Span{Plain("a"), Plain("b")},
+			color:          true,
+			expectedOutput: "ab",
+		},
+		{
+			description:    "Runs of each style wrapped and reset",
+			spans:          []Span{Bold("ab"), Plain(" "), Colored("c", RED)},
+			color:          true,
+			expectedOutput: CSI + "1mab" + RESET + " " + CSI + "31mc" + RESET,
+		},
+		{
+			description: "Combined styles",
+			spans: []Span{{
+				Text:  "x",
+				Style: Style{Bold: true, Underline: true, Foreground: GREEN},
+			}},
+			color:          true,
+			expectedOutput: CSI + "1;4;32mx" + RESET,
+		},
+		{
+			description:    "Colour dropped",
+			spans:          []Span{Colored("a", RED), Underline("b")},
+			color:          false,
+			expectedOutput: "a" + CSI + "4mb" + RESET,
+		},
+		{
+			description:    "Adjacent spans of the same style merged",
+			spans:          []Span{Bold("a"), Bold("b")},
+			color:          true,
+			expectedOutput: CSI + "1mab" + RESET,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			actualOutput := Render(Text(trial.spans...), Styles(trial.spans...), trial.color)
+			assert.Equal(tt, trial.expectedOutput, actualOutput)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+func (terminal *Terminal) SetCompleter(completer completion.Completer) *Terminal {
+	terminal.completer = completer
+	return terminal
+}
+
+// Completes the word before the cursor, listing the candidates if asked twice
+func (terminal *Terminal) Complete() {
+	if terminal.completer == nil {
+		return
+	}
+	value, cursor := terminal.buffer.OutputWithoutPrefix()
+	candidates, start := terminal.completer.Complete(value, cursor)
+
+	repeated := terminal.completionPending &&
+		terminal.completionValue == value &&
+		terminal.completionPosition == cursor
+	terminal.completionPending = false
+
+	switch {
+	case len(candidates) == 0:
+		terminal.Cue(utils.NO_COMPLETIONS)
+		terminal.Announce("no completions")
+		return
+	case len(candidates) == 1:
+		insert := candidates[0].Value
+		if len(insert) > 0 && !os.IsPathSeparator(insert[len(insert)-1]) {
+			insert += " "
+		}
+		terminal.replaceWord(start, insert)
+		return
+	case repeated:
+		terminal.listCandidates(candidates)
+		return
+	}
+
+	prefix := completion.CommonPrefix(candidates)
+	if len(prefix) > cursor-start {
+		terminal.replaceWord(start, prefix)
+	}
+	terminal.completionValue, terminal.completionPosition =
+		terminal.buffer.OutputWithoutPrefix()
+	terminal.completionPending = true
+}
+
+func (terminal *Terminal) replaceWord(start int, input string) {
+	if !terminal.buffer.ReplaceToCursor(start, input) {
+		terminal.RejectInput()
+		return
+	}
+	terminal.Draw()
+}
+
+// Lists candidates one per line so they are read out in order
+func (terminal *Terminal) listCandidates(candidates []completion.Candidate) {
+	lines := []string{fmt.Sprintf("%v completions", len(candidates))}
+	for _, candidate := range candidates {
+		if candidate.Description == "" {
+			lines = append(lines, candidate.Value)
+		} else {
+			lines = append(lines, fmt.Sprintf("%v, %v", candidate.Value, candidate.Description))
+		}
+	}
+	terminal.PrintLines(lines...)
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	buf := buffer.NewBufferWithString("run in")
+	terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetCompleter(completion.NewDescribedWordListCompleter(
+		[]completion.Candidate{
+			{Value: "install", Description: "add a package"},
+			{Value: "instance"},
+			{Value: "remove"},
+		},
+	))
+	terminalUnderTest.Draw()
+
+	terminalUnderTest.Complete()
+	value, _ := terminalUnderTest.CurrentBuffer().OutputWithoutPrefix()
+	assert.Equal(t, "run insta", value)
+
+	file.written = []byte{}
+	terminalUnderTest.Complete()
+	assert.Equal(t, fmtLine(
+		"\n",
+		"2 completions", "\n",
+		"install, add a package", "\n",
+		"instance", "\n",
+		"run insta",
+	), string(file.written))
+	value, _ = terminalUnderTest.CurrentBuffer().OutputWithoutPrefix()
+	assert.Equal(t, "run insta", value)
+
+	terminalUnderTest.CurrentBuffer().AddString("n")
+	terminalUnderTest.Complete()
+	value, _ = terminalUnderTest.CurrentBuffer().OutputWithoutPrefix()
+	assert.Equal(t, "run instance ", value)
+
+	terminalUnderTest.CurrentBuffer().AddString("x")
+	terminalUnderTest.Complete()
+	assert.Equal(t, []string{"no completions"}, announcer.Messages)
+}
+
+func TestCompleteEmptyCandidate(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetCompleter(completion.NewWordListCompleter(""))
+
+	terminalUnderTest.Complete()
+
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "", value)
+}
+
+func TestCompleteRejected(t *testing.T) {
+	buf := buffer.NewBufferWithString("ab")
+	buf.SetMaxLength(4)
+	player := utils.TestCuePlayer{}
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetCuePlayer(&player).
+		SetCompleter(completion.NewWordListCompleter("abcdefgh"))
+
+	terminalUnderTest.Complete()
+
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "ab", value)
+	assert.Equal(t, []utils.Cue{utils.INVALID_KEY}, player.Cues)
+}
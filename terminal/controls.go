@@ -0,0 +1,109 @@
+package terminal
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/style"
+)
+
+// ControlPolicy decides how control characters in the buffer are drawn.  Written as
+// they are, an escape can clear the screen and a carriage return moves the cursor
+// without the terminal knowing, leaving everything drawn after it out of place.
+type ControlPolicy int
+
+const (
+	// Draws control characters in caret notation, such as ^[ for escape
+	CARET_NOTATION ControlPolicy = 0
+	// Leaves control characters out of what is drawn
+	STRIP_CONTROLS ControlPolicy = 1
+	// Writes control characters as they are, for input that is known to be safe
+	RAW_CONTROLS ControlPolicy = 2
+)
+
+// Newlines and tabs are laid out by determineRows, so only the rest are controls
+func isControl(character rune) bool {
+	return (character < 0x20 && character != '\n' && character != '\t') ||
+		(character >= 0x7F && character < 0xA0)
+}
+
+// The text drawn in place of a control character.  C1 controls are shown as their 7
+// bit equivalent behind M-, in the same way as cat -v.
+func (policy ControlPolicy) represent(character rune) string {
+	switch {
+	case policy == STRIP_CONTROLS:
+		return ""
+	case character == 0x7F:
+		return "^?"
+	case character >= 0x80:
+		return "M-^" + string(character-0x80+0x40)
+	}
+	return "^" + string(character+0x40)
+}
+
+// Replaces the control characters in input as the policy says, returning the text to
+// draw along with the index in it of each byte of input, plus one for the end of input.
+// The indices are nil if nothing was replaced.
+func (policy ControlPolicy) sanitise(input string) (string, []int) {
+	if policy == RAW_CONTROLS || strings.IndexFunc(input, isControl) < 0 {
+		return input, nil
+	}
+	builder := strings.Builder{}
+	indices := make([]int, 0, len(input)+1)
+	for i := 0; i < len(input); {
+		character, size := utf8.DecodeRuneInString(input[i:])
+		for j := 0; j < size; j++ {
+			indices = append(indices, builder.Len())
+		}
+		if isControl(character) {
+			builder.WriteString(policy.represent(character))
+		} else {
+			builder.WriteString(input[i : i+size])
+		}
+		i += size
+	}
+	indices = append(indices, builder.Len())
+	return builder.String(), indices
+}
+
+// Where a byte of the original text ended up once sanitised
+func sanitisedIndex(indices []int, index int) int {
+	if indices == nil {
+		return index
+	}
+	if index >= len(indices) {
+		return indices[len(indices)-1]
+	}
+	return indices[index]
+}
+
+// Spreads the style of each control character over the text drawn in its place
+func sanitisedStyles(styles []style.Style, indices []int) []style.Style {
+	if styles == nil || indices == nil {
+		return styles
+	}
+	output := make([]style.Style, 0, indices[len(indices)-1])
+	for i, byteStyle := range styles {
+		if i+1 >= len(indices) {
+			break
+		}
+		for j := indices[i]; j < indices[i+1]; j++ {
+			output = append(output, byteStyle)
+		}
+	}
+	return output
+}
+
+// The buffer's output as it is drawn, with the cursor moved to match
+func (terminal Terminal) displayOutput() (string, int) {
+	data, cursor := terminal.buffer.Output()
+	display, indices := terminal.settings.Controls.sanitise(data)
+	return display, sanitisedIndex(indices, cursor)
+}
+
+// The buffer's previous output as it was drawn, with the cursor moved to match
+func (terminal Terminal) displayPreviousOutput() (string, int) {
+	data, cursor := terminal.buffer.PreviousOutput()
+	display, indices := terminal.settings.Controls.sanitise(data)
+	return display, sanitisedIndex(indices, cursor)
+}
@@ -0,0 +1,123 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitise(t *testing.T) {
+	trials := []struct {
+		description     string
+		policy          ControlPolicy
+		input           string
+		expectedOutput  string
+		expectedIndices []int
+	}{
+		{
+			description:     "Nothing to replace",
+			policy:          CARET_NOTATION,
+			input:           "ls\t-la\n",
+			expectedOutput:  "ls\t-la\n",
+			expectedIndices: nil,
+		},
+		{
+			description:     "Escape and carriage return in caret notation",
+			policy:          CARET_NOTATION,
+			input:           "a\x1b[2J\r",
+			expectedOutput:  "a^[[2J^M",
+			expectedIndices: []int{0, 1, 3, 4, 5, 6, 8},
+		},
+		{
+			description:     "Delete and C1 controls",
+			policy:          CARET_NOTATION,
+			input:           "\x7f\u009b",
+			expectedOutput:  "^?M-^[",
+			expectedIndices: []int{0, 2, 2, 6},
+		},
+		{
+			description:     "Stripped",
+			policy:          STRIP_CONTROLS,
+			input:           "é\x07!",
+			expectedOutput:  "é!",
+			expectedIndices: []int{0, 0, 2, 2, 3},
+		},
+		{
+			description:     "Raw",
+			policy:          RAW_CONTROLS,
+			input:           "\x1b[2J",
+			expectedOutput:  "\x1b[2J",
+			expectedIndices: nil,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			output, indices := trial.policy.sanitise(trial.input)
+			assert.Equal(tt, trial.expectedOutput, output)
+			assert.Equal(tt, trial.expectedIndices, indices)
+		})
+	}
+}
+
+func TestDrawControlCharacters(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		value          string
+		expectedOutput string
+	}{
+		{
+			description:    "Caret notation keeps the cursor in step",
+			settings:       Settings{},
+			value:          "a\rb",
+			expectedOutput: fmtLine("> a^Mb", left(1)),
+		},
+		{
+			description:    "Caret notation wraps by the characters drawn",
+			settings:       Settings{},
+			value:          "\x01\x02\x03\x04x",
+			expectedOutput: fmtLine("> ^A^B^C^", left(9), down(1), "Dx", left(1)),
+		},
+		{
+			description:    "Stripped",
+			settings:       Settings{Controls: STRIP_CONTROLS},
+			value:          "a\x07b",
+			expectedOutput: fmtLine("> ab", left(1)),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString(trial.value)
+			buf.SetPrefix("> ").SetCursor(len(trial.value) - 1)
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 9)
+			terminalUnderTest.SetSettings(trial.settings)
+
+			terminalUnderTest.Draw()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
+
+func TestPrintLinesControlCharacters(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+
+	terminalUnderTest.PrintLines("\x1b[31mred")
+
+	assert.Equal(t, "\n^[[31mred\n", string(file.written))
+}
+
+func TestSanitisedStyles(t *testing.T) {
+	bold := style.Style{Bold: true}
+	_, indices := CARET_NOTATION.sanitise("a\x1bb")
+	assert.Equal(
+		t,
+		[]style.Style{{}, bold, bold, {}},
+		sanitisedStyles([]style.Style{{}, bold, {}}, indices),
+	)
+}
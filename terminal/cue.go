@@ -0,0 +1,25 @@
+package terminal
+
+import "github.com/bekreth/screen_reader_terminal/utils"
+
+// Sets what plays cues, which rings the terminal bell unless changed
+func (terminal *Terminal) SetCuePlayer(player utils.CuePlayer) *Terminal {
+	terminal.cuePlayer = player
+	return terminal
+}
+
+// Stops a single kind of cue being played, or lets it be played again
+func (terminal *Terminal) MuteCue(cue utils.Cue, muted bool) *Terminal {
+	if terminal.mutedCues == nil {
+		terminal.mutedCues = map[utils.Cue]bool{}
+	}
+	terminal.mutedCues[cue] = muted
+	return terminal
+}
+
+// Plays a cue through the terminal's cue player, unless it has been muted
+func (terminal Terminal) Cue(cue utils.Cue) {
+	if terminal.cuePlayer != nil && !terminal.mutedCues[cue] {
+		terminal.cuePlayer.Play(cue)
+	}
+}
@@ -0,0 +1,77 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCues(t *testing.T) {
+	trials := []struct {
+		description  string
+		muted        []utils.Cue
+		play         func(*Terminal)
+		expectedCues []utils.Cue
+	}{
+		{
+			description:  "Rejected input",
+			play:         func(terminal *Terminal) { terminal.AddCharacter('x') },
+			expectedCues: []utils.Cue{utils.INVALID_KEY},
+		},
+		{
+			description:  "Completion with no matches",
+			play:         (*Terminal).Complete,
+			expectedCues: []utils.Cue{utils.NO_COMPLETIONS},
+		},
+		{
+			description:  "Moving up from the first row",
+			play:         func(terminal *Terminal) { terminal.CursorUp() },
+			expectedCues: []utils.Cue{utils.BUFFER_START},
+		},
+		{
+			description:  "Moving down from the last row",
+			play:         func(terminal *Terminal) { terminal.CursorDown() },
+			expectedCues: []utils.Cue{utils.BUFFER_END},
+		},
+		{
+			description: "Muted cues are not played",
+			muted:       []utils.Cue{utils.INVALID_KEY},
+			play: func(terminal *Terminal) {
+				terminal.AddCharacter('x')
+				terminal.Complete()
+			},
+			expectedCues: []utils.Cue{utils.NO_COMPLETIONS},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString("12")
+			buf.SetAllowed("0123456789")
+			player := utils.TestCuePlayer{}
+			terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetCuePlayer(&player)
+			terminalUnderTest.SetCompleter(completion.NewWordListCompleter("install"))
+			for _, cue := range trial.muted {
+				terminalUnderTest.MuteCue(cue, true)
+			}
+
+			trial.play(terminalUnderTest)
+
+			assert.Equal(tt, trial.expectedCues, player.Cues)
+		})
+	}
+}
+
+func TestBellIsTheDefaultCue(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+
+	terminalUnderTest.Cue(utils.BUFFER_END)
+	terminalUnderTest.MuteCue(utils.BUFFER_END, true).Cue(utils.BUFFER_END)
+
+	assert.Equal(t, "\a", string(file.written))
+}
@@ -7,57 +7,67 @@ import (
 )
 
 // Calculates how many rows the current value crosses and on which line
-// the cursor is currently positioned
+// the cursor is currently positioned.  Rows are split by display width, and
+// the cursor offset is in columns.  The index in the value of the first byte
+// of each row is returned last.
 func (terminal Terminal) determineRows(
 	currentValue string,
 	cursor int,
-) ([]string, int, int) {
+) ([]string, int, int, []int) {
 	if currentValue == "" {
-		return []string{}, 0, 0
+		return []string{}, 0, 0, []int{}
 	}
 	width := terminal.window.GetWindowSize().Width
-	splitValues := strings.Split(currentValue, "\n")
+	cursor = utils.IntMin(cursor, len(currentValue))
 
 	rows := []string{}
+	rowStarts := []int{}
 	cursorRows := 0
 	cursorOffset := 0
 
-	cursorCounter := 0
-	advancingCursor := true
-	for i, line := range splitValues {
-		lineLength := len(line)
-		if lineLength == 0 {
-			rows = append(rows, "")
-		}
-		if advancingCursor {
-			cursorOffset = 0
-			if lineLength == 0 {
-				continue
+	lineStart := 0
+	for _, line := range strings.Split(currentValue, "\n") {
+		rowStart := 0
+		column := 0
+		for i, character := range line {
+			characterWidth := columnsOf(character)
+			// Wide characters that do not fit are moved whole onto the next row
+			if column > 0 && column+characterWidth > width {
+				rows = append(rows, line[rowStart:i])
+				rowStarts = append(rowStarts, lineStart+rowStart)
+				rowStart = i
+				column = 0
 			}
-			if i != 0 {
-				cursorCounter += 1
+			if lineStart+i == cursor {
+				cursorRows = len(rows)
+				cursorOffset = column
 			}
+			column += characterWidth
 		}
-
-		for j := 0; j < lineLength; j += width {
-			lineEnd := utils.IntMin(j+width, lineLength)
-			row := strings.ReplaceAll(line[j:lineEnd], "\t", " ")
-			rows = append(rows, row)
-			if advancingCursor {
-				advanceCursorStep := utils.IntMin(len(row), cursor-cursorCounter)
-				cursorCounter += advanceCursorStep
-				cursorOffset = advanceCursorStep % width
-				if cursorCounter >= cursor {
-					if cursorCounter%width == 0 {
-						cursorRows += 1
-					}
-					advancingCursor = false
-				}
-			}
-			if advancingCursor {
+		rows = append(rows, line[rowStart:])
+		rowStarts = append(rowStarts, lineStart+rowStart)
+		if lineStart+len(line) == cursor {
+			cursorRows = len(rows) - 1
+			cursorOffset = column
+			// A cursor after a full row waits at the start of the next
+			if column >= width {
 				cursorRows += 1
+				cursorOffset = 0
 			}
 		}
+		lineStart += len(line) + 1
+	}
+
+	for i, row := range rows {
+		rows[i] = strings.ReplaceAll(row, "\t", " ")
+	}
+	return rows, cursorRows, cursorOffset, rowStarts
+}
+
+// Tabs are drawn as a single space
+func columnsOf(character rune) int {
+	if character == '\t' {
+		return 1
 	}
-	return rows, cursorRows, cursorOffset
+	return utils.RuneWidth(character)
 }
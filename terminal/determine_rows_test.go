@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/bekreth/screen_reader_terminal/buffer"
@@ -15,6 +16,8 @@ func TestDetermineRows(t *testing.T) {
 		basicTests,
 		serveralSmallLines,
 		severalVeryLongLines,
+		continuationLines,
+		wideCharacterLines,
 	}
 
 	trials := []determineRowTrial{}
@@ -36,9 +39,10 @@ func TestDetermineRows(t *testing.T) {
 
 			buf := buffer.NewBuffer()
 			buf.SetCurrentValues(buffer.BufferValues{
-				Prefix:   trial.prefix,
-				Value:    trial.currentValue,
-				Position: trial.currentPosition,
+				Prefix:             trial.prefix,
+				ContinuationPrefix: trial.continuationPrefix,
+				Value:              trial.currentValue,
+				Position:           trial.currentPosition,
 			})
 			buf.SetPreviousValues(buffer.BufferValues{
 				Prefix:   "",
@@ -55,7 +59,7 @@ func TestDetermineRows(t *testing.T) {
 				},
 			)
 			testingValue, testingCursor := terminalUnderTest.CurrentBuffer().Output()
-			actualRow, actualCursor, actualOffset := terminalUnderTest.determineRows(
+			actualRow, actualCursor, actualOffset, _ := terminalUnderTest.determineRows(
 				testingValue,
 				testingCursor,
 			)
@@ -70,9 +74,10 @@ func TestDetermineRows(t *testing.T) {
 type determineRowTrial struct {
 	description string
 
-	prefix          string
-	currentValue    string
-	currentPosition int
+	prefix             string
+	continuationPrefix string
+	currentValue       string
+	currentPosition    int
 
 	expectedRows      []string
 	expectedCursorRow int
@@ -312,3 +317,111 @@ var severalVeryLongLines = []determineRowTrial{
 		expectedOffset:    10,
 	},
 }
+
+var continuationLines = []determineRowTrial{
+	{
+		description:        "continuation prefix, cursor on first line",
+		prefix:             ">>> ",
+		continuationPrefix: "... ",
+		currentPosition:    3,
+		currentValue:       "if x:\n  y",
+		expectedRows:       []string{">>> if x:", "...   y"},
+		expectedCursorRow:  0,
+		expectedOffset:     7,
+	},
+	{
+		description:        "continuation prefix, cursor at start of second line",
+		prefix:             ">>> ",
+		continuationPrefix: "... ",
+		currentPosition:    6,
+		currentValue:       "if x:\n  y",
+		expectedRows:       []string{">>> if x:", "...   y"},
+		expectedCursorRow:  1,
+		expectedOffset:     4,
+	},
+	{
+		description:        "continuation prefix on empty trailing line",
+		prefix:             ">>> ",
+		continuationPrefix: "... ",
+		currentPosition:    6,
+		currentValue:       "if x:\n",
+		expectedRows:       []string{">>> if x:", "... "},
+		expectedCursorRow:  1,
+		expectedOffset:     4,
+	},
+	{
+		description:        "continuation prefix wraps long second line",
+		prefix:             ">>> ",
+		continuationPrefix: "... ",
+		currentPosition:    27,
+		currentValue:       "if x:\n0123456789012345678901",
+		expectedRows:       []string{">>> if x:", "... 0123456789012345", "678901"},
+		expectedCursorRow:  2,
+		expectedOffset:     5,
+	},
+}
+
+var wideCharacterLines = []determineRowTrial{
+	{
+		description:       "multibyte characters take one column",
+		prefix:            "n: ",
+		currentPosition:   5,
+		currentValue:      "café",
+		expectedRows:      []string{"n: café"},
+		expectedCursorRow: 0,
+		expectedOffset:    7,
+	},
+	{
+		description:       "wide characters take two columns",
+		prefix:            "",
+		currentPosition:   37,
+		currentValue:      "日本語日本語日本語日本語x",
+		expectedRows:      []string{"日本語日本語日本語日", "本語x"},
+		expectedCursorRow: 1,
+		expectedOffset:    5,
+	},
+	{
+		description:       "wide character moved whole onto the next row",
+		prefix:            "a",
+		currentPosition:   30,
+		currentValue:      "日本語日本語日本語日",
+		expectedRows:      []string{"a日本語日本語日本語", "日"},
+		expectedCursorRow: 1,
+		expectedOffset:    2,
+	},
+}
+
+func TestDetermineRowStarts(t *testing.T) {
+	trials := []struct {
+		description       string
+		value             string
+		expectedRowStarts []int
+	}{
+		{
+			description:       "Empty value",
+			value:             "",
+			expectedRowStarts: []int{},
+		},
+		{
+			description:       "Lines and wrapped rows",
+			value:             "abc\n\n" + strings.Repeat("x", 25),
+			expectedRowStarts: []int{0, 4, 5, 25},
+		},
+		{
+			description:       "Rows of wide characters start on a whole character",
+			value:             "a" + strings.Repeat("日", 10),
+			expectedRowStarts: []int{0, 28},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+
+			_, _, _, actualRowStarts := terminalUnderTest.determineRows(trial.value, 0)
+
+			assert.Equal(tt, trial.expectedRowStarts, actualRowStarts)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package terminal
 
 import (
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/style"
 	"github.com/bekreth/screen_reader_terminal/utils"
 	"github.com/bekreth/screen_reader_terminal/window"
 )
@@ -9,17 +12,36 @@ func (terminal Terminal) drawRow(
 	previousRowData string,
 	currentRowData string,
 	coords coordinates,
+) coordinates {
+	return terminal.drawStyledRow(previousRowData, currentRowData, coords, nil, -1)
+}
+
+// Draws the changes to a row in its styles, redrawing from styleChange if it is not -1
+func (terminal Terminal) drawStyledRow(
+	previousRowData string,
+	currentRowData string,
+	coords coordinates,
+	styles []style.Style,
+	styleChange int,
 ) coordinates {
 	var newEnd string
-	var column int
+	var start int
 	shouldClearFromCursor := false
 	if previousRowData == "" {
 		newEnd = currentRowData
 	} else {
-		newEnd, column = rowDiff(previousRowData, currentRowData)
-		coords = coords.setPendingColumn(column)
-		shouldClearFromCursor = len(previousRowData) > len(currentRowData) &&
-			previousRowData != emptyString
+		newEnd, start = rowDiff(previousRowData, currentRowData)
+		if styleChange >= 0 && styleChange < start {
+			start = styleChange
+		}
+		// Redraw whole characters, even when only their last bytes changed
+		for start > 0 && start < len(currentRowData) && !utf8.RuneStart(currentRowData[start]) {
+			start -= 1
+		}
+		newEnd = currentRowData[start:]
+		coords = coords.setPendingColumn(utils.DisplayWidth(currentRowData[:start]))
+		shouldClearFromCursor = previousRowData != emptyString &&
+			utils.DisplayWidth(previousRowData) > utils.DisplayWidth(currentRowData)
 	}
 
 	xMove, yMove := coords.outputDelataToTarget()
@@ -30,9 +52,13 @@ func (terminal Terminal) drawRow(
 		terminal.window.ClearLine(window.CURSOR_FORWARD)
 	}
 
-	terminal.window.Write([]byte(newEnd))
+	terminal.window.Write([]byte(style.Render(
+		newEnd,
+		stylesFrom(styles, start),
+		!terminal.settings.NoColor,
+	)))
 
-	coords = coords.addColumnDelta(len(newEnd))
+	coords = coords.addColumnDelta(utils.DisplayWidth(newEnd))
 	coords = coords.applyPendingDeltas()
 	return coords
 }
@@ -19,6 +19,22 @@ type drawRowTrial struct {
 
 func TestDrawRow(t *testing.T) {
 	trials := []drawRowTrial{
+		{
+			description:      "Changing a multibyte character redraws it whole",
+			previousLineData: "日本é",
+			currentLineData:  "日本è",
+			cursorCoordinates: coordinates{
+				currentX: 5,
+				currentY: 0,
+			},
+			expectedWrite: fmtLine(left(1), "è"),
+			expectedCoordinates: coordinates{
+				currentX:      5,
+				currentY:      0,
+				pendingDeltaX: 5,
+				pendingDeltaY: 0,
+			},
+		},
 		{
 			description:      "Simple append to row",
 			previousLineData: "",
@@ -0,0 +1,39 @@
+package terminal
+
+func (terminal *Terminal) KillWordForward() {
+	terminal.reportKill(terminal.buffer.KillWordForward(terminal.killRing))
+}
+
+func (terminal *Terminal) KillWordBackward() {
+	terminal.reportKill(terminal.buffer.KillWordBackward(terminal.killRing))
+}
+
+func (terminal *Terminal) KillToLineStart() {
+	terminal.reportKill(terminal.buffer.KillToLineStart(terminal.killRing))
+}
+
+func (terminal *Terminal) KillToLineEnd() {
+	terminal.reportKill(terminal.buffer.KillToLineEnd(terminal.killRing))
+}
+
+func (terminal *Terminal) KillLine() {
+	terminal.reportKill(terminal.buffer.KillLine(terminal.killRing))
+}
+
+func (terminal *Terminal) Yank() {
+	terminal.buffer.Yank(terminal.killRing)
+	terminal.Draw()
+}
+
+func (terminal *Terminal) YankPop() {
+	terminal.buffer.YankPop(terminal.killRing)
+	terminal.Draw()
+}
+
+// Redraws after a kill and announces the removed text
+func (terminal *Terminal) reportKill(removed string) {
+	terminal.Draw()
+	if removed != "" {
+		terminal.Announce("removed " + removed)
+	}
+}
@@ -0,0 +1,22 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKillAnnouncesRemovedText(t *testing.T) {
+	buf := buffer.NewBufferWithString("Hello world")
+	terminalUnderTest, _, announcer := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.Draw()
+
+	terminalUnderTest.KillWordBackward()
+	terminalUnderTest.KillWordBackward()
+	terminalUnderTest.Yank()
+
+	assert.Equal(t, []string{"removed world", "removed Hello "}, announcer.Messages)
+	value, _ := terminalUnderTest.CurrentBuffer().OutputWithoutPrefix()
+	assert.Equal(t, "Hello world", value)
+}
@@ -0,0 +1,28 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskedBufferDrawAndHistory(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("pin: ").SetMask(0)
+	terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 20)
+
+	buf.AddString("12")
+	terminalUnderTest.Draw()
+	buf.AddCharacter('3')
+	terminalUnderTest.Draw()
+	terminalUnderTest.Draw()
+
+	assert.Equal(t, "pin: ", string(file.written))
+	assert.Equal(t, []string{"2 characters", "3 characters"}, announcer.Messages)
+
+	terminalUnderTest.NewLine()
+	previous := terminalUnderTest.PreviousBuffer()
+	assert.True(t, previous.IsEmpty())
+	assert.Equal(t, 0, len(buf.Secret()))
+}
@@ -0,0 +1,79 @@
+package terminal
+
+import "github.com/bekreth/screen_reader_terminal/utils"
+
+// InputComplete decides if enter submits the buffer or starts a new line
+type InputComplete func(value string) bool
+
+// Sets the check used by Enter.  Without one, enter always submits the buffer.
+func (terminal *Terminal) SetInputComplete(inputComplete InputComplete) *Terminal {
+	terminal.inputComplete = inputComplete
+	return terminal
+}
+
+// Moves the cursor up a row of the buffer, cueing and returning false if already on the
+// first
+func (terminal *Terminal) CursorUp() bool {
+	moved := terminal.buffer.CursorUp(terminal.window.GetWindowSize().Width)
+	if !moved {
+		terminal.Cue(utils.BUFFER_START)
+	}
+	terminal.Draw()
+	return moved
+}
+
+// Moves the cursor down a row of the buffer, cueing and returning false if already on
+// the last
+func (terminal *Terminal) CursorDown() bool {
+	moved := terminal.buffer.CursorDown(terminal.window.GetWindowSize().Width)
+	if !moved {
+		terminal.Cue(utils.BUFFER_END)
+	}
+	terminal.Draw()
+	return moved
+}
+
+// Submits the buffer if the input is complete, otherwise inserts a newline
+func (terminal *Terminal) Enter() bool {
+	value, _ := terminal.buffer.OutputWithoutPrefix()
+	if terminal.inputComplete != nil && !terminal.inputComplete(value) {
+		terminal.buffer.AddCharacter('\n')
+		terminal.Draw()
+		return false
+	}
+	terminal.buffer.CursorToEnd()
+	terminal.Draw()
+	terminal.NewLine()
+	return true
+}
+
+// BracketsBalanced is complete once every bracket outside quotes is closed
+func BracketsBalanced(value string) bool {
+	closers := map[rune]rune{'(': ')', '[': ']', '{': '}'}
+	expected := []rune{}
+	var quote rune
+	escaped := false
+	for _, character := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case character == '\\':
+			escaped = true
+		case quote != 0:
+			if character == quote {
+				quote = 0
+			}
+		case character == '"' || character == '\'' || character == '`':
+			quote = character
+		case closers[character] != 0:
+			expected = append(expected, closers[character])
+		case character == ')' || character == ']' || character == '}':
+			if len(expected) == 0 || expected[len(expected)-1] != character {
+				// A stray closer can never be balanced by more lines
+				return true
+			}
+			expected = expected[:len(expected)-1]
+		}
+	}
+	return len(expected) == 0 && quote == 0
+}
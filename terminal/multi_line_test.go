@@ -0,0 +1,70 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBracketsBalanced(t *testing.T) {
+	trials := []struct {
+		description    string
+		input          string
+		expectedOutput bool
+	}{
+		{
+			description:    "Empty input is complete",
+			input:          "",
+			expectedOutput: true,
+		},
+		{
+			description:    "Open bracket is incomplete",
+			input:          "func main() {",
+			expectedOutput: false,
+		},
+		{
+			description:    "Nested brackets closed",
+			input:          "x = [(1, 2), {3: 4}]",
+			expectedOutput: true,
+		},
+		{
+			description:    "Brackets in quotes are ignored",
+			input:          "print(\"(\")",
+			expectedOutput: true,
+		},
+		{
+			description:    "Open quote is incomplete",
+			input:          "print(\"hello",
+			expectedOutput: false,
+		},
+		{
+			description:    "Stray closer can not be completed",
+			input:          "}",
+			expectedOutput: true,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			assert.Equal(tt, trial.expectedOutput, BracketsBalanced(trial.input))
+		})
+	}
+}
+
+func TestEnter(t *testing.T) {
+	buf := buffer.NewBufferWithString("if (x {")
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetInputComplete(BracketsBalanced)
+
+	assert.False(t, terminalUnderTest.Enter())
+	value, _ := terminalUnderTest.CurrentBuffer().OutputWithoutPrefix()
+	assert.Equal(t, "if (x {\n", value)
+
+	terminalUnderTest.CurrentBuffer().AddString("})")
+	assert.True(t, terminalUnderTest.Enter())
+	assert.True(t, terminalUnderTest.CurrentBuffer().IsEmpty())
+	previousBuffer := terminalUnderTest.PreviousBuffer()
+	previousValue, _ := previousBuffer.OutputWithoutPrefix()
+	assert.Equal(t, "if (x {\n})", previousValue)
+}
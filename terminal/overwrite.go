@@ -0,0 +1,15 @@
+package terminal
+
+// Switches between inserting and overwriting and announces the new mode
+func (terminal *Terminal) ToggleOverwrite() {
+	if terminal.buffer.ToggleOverwrite() {
+		terminal.Announce("overwrite")
+	} else {
+		terminal.Announce("insert")
+	}
+}
+
+// Whether typing overwrites the text under the cursor
+func (terminal Terminal) IsOverwrite() bool {
+	return terminal.buffer.IsOverwrite()
+}
@@ -0,0 +1,28 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToggleOverwrite(t *testing.T) {
+	buf := buffer.NewBufferWithString("cat")
+	buf.SetPrefix("> ").SetCursor(0)
+	terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 40)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	terminalUnderTest.ToggleOverwrite()
+	assert.True(t, terminalUnderTest.IsOverwrite())
+	buf.AddCharacter('b')
+	terminalUnderTest.Draw()
+	terminalUnderTest.ToggleOverwrite()
+	assert.False(t, terminalUnderTest.IsOverwrite())
+
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "bat", value)
+	assert.Equal(t, fmtLine("bat", left(2)), string(file.written))
+	assert.Equal(t, []string{"overwrite", "insert"}, announcer.Messages)
+}
@@ -0,0 +1,370 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+// Shows lines a screenful at a time like less, or prints them if they already fit
+func (terminal *Terminal) Page(reader input.KeyReader, lines ...string) error {
+	pager := pager{
+		terminal: terminal,
+		lines:    lines,
+		found:    -1,
+		buffer:   buffer.NewBuffer(),
+	}
+	if pager.rowsBetween(0, len(lines)) <= pager.pageSize() {
+		terminal.PrintLines(lines...)
+		return nil
+	}
+
+	previous := terminal.ReplaceBuffer(&pager.buffer)
+	defer pager.leave(previous)
+	pager.draw()
+	pager.announcePage()
+	for {
+		key, err := reader.ReadKey()
+		if err != nil {
+			return err
+		}
+		if !pager.handleKey(key) {
+			return nil
+		}
+	}
+}
+
+type pager struct {
+	terminal *Terminal
+	lines    []string
+	buffer   buffer.Buffer
+
+	// The page shown runs from top up to, but not including, bottom
+	top    int
+	bottom int
+
+	reviewing bool
+	current   int
+
+	searching bool
+	query     string
+	found     int
+}
+
+// Applies a key, returning false once the pager should close
+func (pager *pager) handleKey(key input.Key) bool {
+	if pager.searching {
+		pager.handleSearchKey(key)
+		return true
+	}
+
+	switch {
+	case key.Code == input.ESCAPE && pager.reviewing,
+		key.Code == input.RUNE && key.Rune == 'r':
+		pager.toggleReview()
+		return true
+	case key.Code == input.ESCAPE,
+		key.Code == input.RUNE && key.Rune == 'q':
+		return false
+	case key.Code == input.RUNE && key.Rune == '/':
+		pager.searching = true
+		pager.query = ""
+		pager.draw()
+		return true
+	case key.Code == input.RUNE && key.Rune == 'n':
+		pager.search(1)
+		return true
+	case key.Code == input.RUNE && key.Rune == 'N':
+		pager.search(-1)
+		return true
+	}
+
+	if pager.reviewing {
+		pager.handleReviewKey(key)
+		return true
+	}
+
+	switch {
+	case key.Code == input.PAGE_DOWN,
+		key.Code == input.RUNE && (key.Rune == ' ' || key.Rune == 'f'):
+		if pager.bottom >= len(pager.lines) {
+			return false
+		}
+		pager.showPage(utils.IntMin(pager.bottom, pager.lastTop()))
+	case key.Code == input.PAGE_UP,
+		key.Code == input.RUNE && key.Rune == 'b':
+		pager.showPage(pager.previousTop())
+	case key.Code == input.DOWN || key.Code == input.ENTER,
+		key.Code == input.RUNE && key.Rune == 'j':
+		if pager.top < pager.lastTop() {
+			pager.top += 1
+			pager.draw()
+			pager.terminal.Announce(spokenLine(pager.lines[pager.bottom-1]))
+		}
+	case key.Code == input.UP,
+		key.Code == input.RUNE && key.Rune == 'k':
+		if pager.top > 0 {
+			pager.top -= 1
+			pager.draw()
+			pager.terminal.Announce(spokenLine(pager.lines[pager.top]))
+		}
+	case key.Code == input.HOME,
+		key.Code == input.RUNE && key.Rune == 'g':
+		pager.showPage(0)
+	case key.Code == input.END,
+		key.Code == input.RUNE && key.Rune == 'G':
+		pager.showPage(pager.lastTop())
+	}
+	return true
+}
+
+func (pager *pager) handleReviewKey(key input.Key) {
+	switch {
+	case key.Code == input.DOWN || key.Code == input.ENTER,
+		key.Code == input.RUNE && key.Rune == 'j':
+		if pager.current == len(pager.lines)-1 {
+			pager.terminal.Cue(utils.BUFFER_END)
+			pager.terminal.Announce("bottom")
+			return
+		}
+		pager.reviewLine(pager.current + 1)
+	case key.Code == input.UP,
+		key.Code == input.RUNE && key.Rune == 'k':
+		if pager.current == 0 {
+			pager.terminal.Cue(utils.BUFFER_START)
+			pager.terminal.Announce("top")
+			return
+		}
+		pager.reviewLine(pager.current - 1)
+	case key.Code == input.PAGE_DOWN,
+		key.Code == input.RUNE && (key.Rune == ' ' || key.Rune == 'f'):
+		pager.reviewLine(utils.IntMin(pager.bottom, len(pager.lines)-1))
+	case key.Code == input.PAGE_UP,
+		key.Code == input.RUNE && key.Rune == 'b':
+		pager.reviewLine(pager.previousTop())
+	case key.Code == input.HOME,
+		key.Code == input.RUNE && key.Rune == 'g':
+		pager.reviewLine(0)
+	case key.Code == input.END,
+		key.Code == input.RUNE && key.Rune == 'G':
+		pager.reviewLine(len(pager.lines) - 1)
+	}
+}
+
+func (pager *pager) handleSearchKey(key input.Key) {
+	switch key.Code {
+	case input.RUNE:
+		pager.query += string(key.Rune)
+	case input.BACKSPACE:
+		if pager.query == "" {
+			pager.searching = false
+		} else {
+			_, size := utf8.DecodeLastRuneInString(pager.query)
+			pager.query = pager.query[:len(pager.query)-size]
+		}
+	case input.ESCAPE:
+		pager.searching = false
+	case input.ENTER:
+		pager.searching = false
+		if pager.query != "" {
+			pager.found = -1
+			pager.search(1)
+			return
+		}
+	}
+	pager.draw()
+}
+
+func (pager *pager) toggleReview() {
+	pager.reviewing = !pager.reviewing
+	if !pager.reviewing {
+		pager.draw()
+		pager.terminal.Announce("review off")
+		return
+	}
+	pager.terminal.Announce("review on")
+	pager.reviewLine(pager.top)
+}
+
+// Moves the review cursor to a line, scrolling it into view, and reads it out
+func (pager *pager) reviewLine(line int) {
+	pager.current = line
+	pager.scrollTo(line)
+	pager.draw()
+	pager.terminal.Announce(spokenLine(pager.lines[line]))
+}
+
+// Finds the next line containing the query ignoring case, backwards if step is negative
+func (pager *pager) search(step int) {
+	if pager.query == "" {
+		pager.terminal.Announce("no search")
+		return
+	}
+	start := pager.found
+	if pager.reviewing {
+		start = pager.current
+	} else if pager.found < pager.top || pager.found >= pager.bottom {
+		start = pager.top - 1
+		if step < 0 {
+			start = pager.bottom
+		}
+	}
+
+	query := strings.ToLower(pager.query)
+	for i := start + step; i >= 0 && i < len(pager.lines); i += step {
+		if strings.Contains(strings.ToLower(pager.lines[i]), query) {
+			pager.found = i
+			if pager.reviewing {
+				pager.current = i
+			}
+			pager.scrollTo(i)
+			pager.draw()
+			pager.terminal.Announce(fmt.Sprintf("line %v: %v", i+1, pager.lines[i]))
+			return
+		}
+	}
+	pager.draw()
+	pager.terminal.Announce(fmt.Sprintf("not found: %v", pager.query))
+}
+
+func (pager *pager) showPage(top int) {
+	pager.top = top
+	pager.draw()
+	pager.announcePage()
+}
+
+func (pager *pager) announcePage() {
+	pager.terminal.Announce(fmt.Sprintf(
+		"lines %v to %v of %v",
+		pager.top+1,
+		pager.bottom,
+		len(pager.lines),
+	))
+}
+
+// Moves the page the least distance that brings a line into view
+func (pager *pager) scrollTo(line int) {
+	if line < pager.top {
+		pager.top = line
+		return
+	}
+	pager.layout()
+	for line >= pager.bottom {
+		pager.top += 1
+		pager.layout()
+	}
+}
+
+// Works out where the page starting at top ends
+func (pager *pager) layout() {
+	size := pager.pageSize()
+	rows := 0
+	pager.bottom = pager.top
+	for pager.bottom < len(pager.lines) {
+		rows += pager.rowsBetween(pager.bottom, pager.bottom+1)
+		if rows > size && pager.bottom > pager.top {
+			break
+		}
+		pager.bottom += 1
+	}
+}
+
+func (pager *pager) draw() {
+	pager.layout()
+	page := strings.Join(pager.lines[pager.top:pager.bottom], "\n")
+	pager.buffer.SetString(page + "\n" + pager.status())
+	if pager.reviewing && !pager.searching {
+		cursor := 0
+		for _, line := range pager.lines[pager.top:pager.current] {
+			cursor += len(line) + 1
+		}
+		pager.buffer.SetCursor(cursor)
+	}
+	pager.terminal.Draw()
+}
+
+func (pager pager) status() string {
+	switch {
+	case pager.searching:
+		return "/" + pager.query
+	case pager.reviewing:
+		return fmt.Sprintf("line %v of %v, r to stop", pager.current+1, len(pager.lines))
+	}
+	return fmt.Sprintf(
+		"lines %v-%v of %v, space for more, q to quit",
+		pager.top+1,
+		pager.bottom,
+		len(pager.lines),
+	)
+}
+
+// Rows available for output, leaving room for the status line
+func (pager pager) pageSize() int {
+	statusRows, _, _, _ := pager.terminal.determineRows(pager.status(), 0)
+	height := pager.terminal.window.GetWindowSize().Height
+	return utils.IntMax(height-len(statusRows), 1)
+}
+
+// Rows taken up on screen by the lines from start up to, but not including, end
+func (pager pager) rowsBetween(start int, end int) int {
+	rows := 0
+	for _, line := range pager.lines[start:end] {
+		lineRows, _, _, _ := pager.terminal.determineRows(line, 0)
+		rows += utils.IntMax(len(lineRows), 1)
+	}
+	return rows
+}
+
+// The top of the final page
+func (pager pager) lastTop() int {
+	return pager.topBefore(len(pager.lines))
+}
+
+// The top of the page before the current one
+func (pager pager) previousTop() int {
+	return pager.topBefore(pager.top)
+}
+
+// The top of the fullest page ending just before end
+func (pager pager) topBefore(end int) int {
+	size := pager.pageSize()
+	top := end
+	for top > 0 && pager.rowsBetween(top-1, end) <= size {
+		top -= 1
+	}
+	if top == end && end > 0 {
+		top -= 1
+	}
+	return top
+}
+
+// Leaves the page on screen, keeping every line in the scrollback, and redraws the buffer
+func (pager *pager) leave(previous *buffer.Buffer) {
+	pager.layout()
+	pager.recordLines(pager.lines[:pager.top])
+	pager.buffer.SetString(strings.Join(pager.lines[pager.top:pager.bottom], "\n"))
+	pager.terminal.EndLine()
+	pager.recordLines(pager.lines[pager.bottom:])
+	pager.terminal.SwapBuffer(previous)
+	previous.ClearPrevious()
+	pager.terminal.Draw()
+}
+
+func (pager *pager) recordLines(lines []string) {
+	for _, line := range lines {
+		sanitised, _ := pager.terminal.settings.Controls.sanitise(line)
+		pager.terminal.recordLines(sanitised)
+	}
+}
+
+// A line as read out, naming blank lines so the screen reader does not fall silent
+func spokenLine(line string) string {
+	if strings.TrimSpace(line) == "" {
+		return "blank"
+	}
+	return line
+}
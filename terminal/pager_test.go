@@ -0,0 +1,155 @@
+package terminal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func numberedLines(count int) []string {
+	output := make([]string, count)
+	for i := range output {
+		output[i] = fmt.Sprintf("line %v", i+1)
+	}
+	return output
+}
+
+func TestPager(t *testing.T) {
+	down := input.Key{Code: input.DOWN}
+	up := input.Key{Code: input.UP}
+	enter := input.Key{Code: input.ENTER}
+
+	trials := []struct {
+		description      string
+		lines            []string
+		keys             []input.Key
+		expectedMessages []string
+	}{
+		{
+			description: "Paging to the end closes the pager",
+			lines:       numberedLines(12),
+			keys:        input.RuneKeys("   "),
+			expectedMessages: []string{
+				"lines 1 to 5 of 12",
+				"lines 6 to 10 of 12",
+				"lines 8 to 12 of 12",
+			},
+		},
+		{
+			description: "Paging back and scrolling a line",
+			lines:       numberedLines(12),
+			keys:        append(input.RuneKeys(" bj"), input.Key{Code: input.ESCAPE}),
+			expectedMessages: []string{
+				"lines 1 to 5 of 12",
+				"lines 6 to 10 of 12",
+				"lines 1 to 5 of 12",
+				"line 6",
+			},
+		},
+		{
+			description: "Reviewing line by line",
+			lines:       []string{"one", "", "three", "four", "five", "six", "seven"},
+			keys: append(
+				input.RuneKeys("r"),
+				up, down, down, down, down, down, down, down, input.RuneKey('q'),
+			),
+			expectedMessages: []string{
+				"lines 1 to 5 of 7",
+				"review on",
+				"one",
+				"top",
+				"blank",
+				"three",
+				"four",
+				"five",
+				"six",
+				"seven",
+				"bottom",
+			},
+		},
+		{
+			description: "Searching forwards and backwards",
+			lines: []string{
+				"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta",
+			},
+			keys: append(
+				append(input.RuneKeys("/ETA"), enter),
+				input.RuneKeys("nnnNq")...,
+			),
+			expectedMessages: []string{
+				"lines 1 to 5 of 8",
+				"line 2: beta",
+				"line 6: zeta",
+				"line 7: eta",
+				"line 8: theta",
+				"line 7: eta",
+			},
+		},
+		{
+			description: "Missing search",
+			lines:       numberedLines(8),
+			keys:        append(append(input.RuneKeys("/nine"), enter), input.RuneKeys("q")...),
+			expectedMessages: []string{
+				"lines 1 to 5 of 8",
+				"not found: nine",
+			},
+		},
+		{
+			description:      "Output that fits is printed without waiting",
+			lines:            numberedLines(3),
+			keys:             []input.Key{},
+			expectedMessages: nil,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ")
+			terminalUnderTest, _, announcer := newTestTerminal(&buf, 6, 60)
+
+			reader := input.TestReader{Keys: trial.keys}
+			err := terminalUnderTest.Page(&reader, trial.lines...)
+
+			assert.Nil(tt, err)
+			assert.Equal(tt, trial.expectedMessages, announcer.Messages)
+			assert.Equal(tt, &buf, terminalUnderTest.CurrentBuffer())
+		})
+	}
+}
+
+func TestPagerLeavesPageOnScreen(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ")
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 3, 60)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	reader := input.TestReader{Keys: input.RuneKeys("q")}
+	terminalUnderTest.Page(&reader, "a", "b", "c")
+
+	assert.Equal(
+		t,
+		fmtLine(
+			left(2), clearForward(),
+			"a", left(1), down(1), "b", left(1), down(1),
+			"lines 1-2 of 3, space for more, q to quit",
+			left(41), clearCursorFullLine(), right(1), up(1),
+			"\n", "> ",
+		),
+		string(file.written),
+	)
+}
+
+func TestPagerKeepsEveryLineInScrollback(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 6, 60)
+
+	reader := input.TestReader{Keys: input.RuneKeys(" q")}
+	terminalUnderTest.Page(&reader, numberedLines(12)...)
+
+	assert.Equal(t, numberedLines(12), terminalUnderTest.scrollback.Lines())
+}
@@ -0,0 +1,44 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Asks the terminal to mark pasted text, so it arrives as a single PASTE key
+func (terminal *Terminal) EnableBracketedPaste() *Terminal {
+	terminal.window.EnableBracketedPaste()
+	return terminal
+}
+
+func (terminal *Terminal) DisableBracketedPaste() *Terminal {
+	terminal.window.DisableBracketedPaste()
+	return terminal
+}
+
+// Adds pasted text in a single redraw and announces its size, or rejects it whole
+func (terminal *Terminal) Paste(text string) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	if text == "" {
+		return
+	}
+	if !terminal.buffer.AddString(text) {
+		terminal.RejectInput()
+		return
+	}
+	terminal.Draw()
+	terminal.Announce(fmt.Sprintf(
+		"pasted %v, %v",
+		plural(utf8.RuneCountInString(text), "character"),
+		plural(strings.Count(text, "\n")+1, "line"),
+	))
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %v", noun)
+	}
+	return fmt.Sprintf("%v %vs", count, noun)
+}
@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaste(t *testing.T) {
+	trials := []struct {
+		description      string
+		text             string
+		expectedValue    string
+		expectedOutput   string
+		expectedMessages []string
+	}{
+		{
+			description:      "Single line",
+			text:             "ls -la",
+			expectedValue:    "> ls -la",
+			expectedOutput:   "ls -la",
+			expectedMessages: []string{"pasted 6 characters, 1 line"},
+		},
+		{
+			description:      "Line endings become newlines",
+			text:             "a\r\nb\rc",
+			expectedValue:    "> a\n. b\n. c",
+			expectedOutput:   fmtLine("a", left(3), down(1), ". b", left(3), down(1), ". c"),
+			expectedMessages: []string{"pasted 5 characters, 3 lines"},
+		},
+		{
+			description:      "Nothing pasted",
+			text:             "",
+			expectedValue:    "> ",
+			expectedOutput:   "",
+			expectedMessages: nil,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ").SetContinuationPrefix(". ")
+			terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.Draw()
+			file.written = []byte{}
+
+			terminalUnderTest.Paste(trial.text)
+
+			value, _ := buf.Output()
+			assert.Equal(tt, trial.expectedValue, value)
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+			assert.Equal(tt, trial.expectedMessages, announcer.Messages)
+		})
+	}
+}
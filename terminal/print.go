@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+// Prints lines below the buffer, then draws the buffer again beneath them
+func (terminal *Terminal) PrintLines(lines ...string) {
+	sanitised := make([]string, len(lines))
+	for i, line := range lines {
+		sanitised[i], _ = terminal.settings.Controls.sanitise(line)
+	}
+	terminal.printLines(sanitised, sanitised)
+}
+
+// Prints lines made up of styled spans in the same way as PrintLines
+func (terminal *Terminal) PrintStyledLines(lines ...[]style.Span) {
+	plain := make([]string, len(lines))
+	rendered := make([]string, len(lines))
+	for i, spans := range lines {
+		spans = terminal.sanitiseSpans(spans)
+		plain[i] = style.Text(spans...)
+		rendered[i] = plain[i]
+		if !terminal.settings.DisableStyles {
+			rendered[i] = style.Render(
+				plain[i],
+				style.Styles(spans...),
+				!terminal.settings.NoColor,
+			)
+		}
+	}
+	terminal.printLines(plain, rendered)
+}
+
+// The spans with control characters in their text replaced, keeping their styles
+func (terminal Terminal) sanitiseSpans(spans []style.Span) []style.Span {
+	output := make([]style.Span, len(spans))
+	for i, span := range spans {
+		output[i] = span
+		output[i].Text, _ = terminal.settings.Controls.sanitise(span.Text)
+	}
+	return output
+}
+
+// Prints rendered lines, measuring and recording the matching plain lines
+func (terminal *Terminal) printLines(lines []string, rendered []string) {
+	terminal.clearSuggestion()
+	currentData, currentCursor := terminal.displayOutput()
+	rows, cursorRow, _, _ := terminal.determineRows(currentData, currentCursor)
+	// cursorHeight already tracks the last row of the buffer, so only the cursor moves
+	if len(rows) > cursorRow+1 {
+		terminal.window.MoveCursor(0, len(rows)-cursorRow-1)
+	}
+
+	terminal.writeLine("")
+	terminal.recordLines(lines...)
+	for i, line := range lines {
+		terminal.window.Write([]byte(rendered[i]))
+		lineRows, _, _, _ := terminal.determineRows(line, 0)
+		terminal.cursorHeight = utils.IntMin(
+			terminal.cursorHeight+len(lineRows)-1,
+			terminal.window.GetWindowSize().Height-1,
+		)
+		terminal.writeLine("")
+	}
+
+	terminal.promptMarked = false
+	terminal.buffer.ClearPrevious()
+	terminal.Draw()
+}
+
+// Writes the input followed by a newline, tracking the cursor height
+func (terminal *Terminal) writeLine(input string) {
+	terminal.window.Write([]byte(input + "\n"))
+	height := terminal.window.GetWindowSize().Height
+	if terminal.cursorHeight+1 < height {
+		terminal.cursorHeight += 1
+	}
+}
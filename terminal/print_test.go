@@ -0,0 +1,54 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintLinesCursorHeight(t *testing.T) {
+	trials := []struct {
+		description          string
+		height               int
+		value                string
+		cursor               int
+		lines                []string
+		expectedOutput       string
+		expectedCursorHeight int
+	}{
+		{
+			description:          "Buffer rows below the cursor are counted once",
+			height:               20,
+			value:                "abcdef",
+			cursor:               0,
+			lines:                []string{"x"},
+			expectedOutput:       fmtLine(down(1), "\n", "x\n", "> abc", left(5), down(1), "def", left(1), up(1)),
+			expectedCursorHeight: 4,
+		},
+		{
+			description:          "Wrapped lines stop at the bottom of the window without scrolling",
+			height:               4,
+			value:                "",
+			cursor:               0,
+			lines:                []string{"aaaaaaaaaaaaaaaaaaaaaaaaa", "b"},
+			expectedOutput:       "\naaaaaaaaaaaaaaaaaaaaaaaaa\nb\n> ",
+			expectedCursorHeight: 3,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString(trial.value)
+			buf.SetPrefix("> ").SetCursor(trial.cursor)
+			terminalUnderTest, file, _ := newTestTerminal(&buf, trial.height, 5)
+			terminalUnderTest.Draw()
+			file.written = []byte{}
+
+			terminalUnderTest.PrintLines(trial.lines...)
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+			assert.Equal(tt, trial.expectedCursorHeight, terminalUnderTest.cursorHeight)
+		})
+	}
+}
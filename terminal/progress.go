@@ -0,0 +1,166 @@
+package terminal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+)
+
+const (
+	DEFAULT_PERCENT_STEP = 10
+	DEFAULT_INTERVAL     = 30 * time.Second
+)
+
+// Progress shows a status line for long running work, announcing only milestones
+type Progress struct {
+	terminal *Terminal
+	label    string
+	total    int
+	current  int
+
+	percentStep int
+	interval    time.Duration
+	now         func() time.Time
+
+	status   buffer.Buffer
+	previous *buffer.Buffer
+	started  bool
+
+	lastMilestone int
+	lastAnnounced time.Time
+}
+
+// Creates a progress reporter for total units of work, or an unknown amount if 0
+func (terminal *Terminal) NewProgress(label string, total int) *Progress {
+	return &Progress{
+		terminal:    terminal,
+		label:       label,
+		total:       total,
+		percentStep: DEFAULT_PERCENT_STEP,
+		interval:    DEFAULT_INTERVAL,
+		now:         time.Now,
+		status:      buffer.NewBuffer(),
+	}
+}
+
+// Announces every percentStep percent and every interval, with 0 turning either off
+func (progress *Progress) SetMilestones(percentStep int, interval time.Duration) *Progress {
+	progress.percentStep = percentStep
+	progress.interval = interval
+	return progress
+}
+
+// Replaces the clock used for the interval milestones
+func (progress *Progress) SetClock(now func() time.Time) *Progress {
+	progress.now = now
+	return progress
+}
+
+// Draws the status line in place of the buffer and announces the start
+func (progress *Progress) Start() {
+	if progress.started {
+		return
+	}
+	progress.started = true
+	progress.lastAnnounced = progress.now()
+	progress.status.SetString(progress.statusText())
+	progress.previous = progress.terminal.ReplaceBuffer(&progress.status)
+	progress.terminal.Announce(fmt.Sprintf("%v started", progress.label))
+}
+
+// Records the work done, announcing milestones but leaving 100% to Complete
+func (progress *Progress) Update(current int) {
+	if !progress.started {
+		progress.Start()
+	}
+	progress.current = current
+	progress.status.SetString(progress.statusText())
+	progress.terminal.Draw()
+
+	now := progress.now()
+	milestone := progress.milestone()
+	if progress.percent() == 100 {
+		progress.lastMilestone = milestone
+		return
+	}
+	switch {
+	case milestone > progress.lastMilestone:
+		progress.lastMilestone = milestone
+	case progress.interval > 0 && now.Sub(progress.lastAnnounced) >= progress.interval:
+	default:
+		return
+	}
+	progress.lastAnnounced = now
+	progress.terminal.Announce(progress.spokenProgress())
+}
+
+// Marks the work as done and draws the buffer again beneath the status
+func (progress *Progress) Complete() {
+	progress.finish("done", fmt.Sprintf("%v complete", progress.label))
+}
+
+// Marks the work as failed and draws the buffer again beneath the error
+func (progress *Progress) Fail(err error) {
+	progress.finish(
+		fmt.Sprintf("failed, %v", err.Error()),
+		fmt.Sprintf("%v failed, %v", progress.label, err.Error()),
+	)
+}
+
+func (progress *Progress) finish(status string, message string) {
+	if !progress.started {
+		progress.Start()
+	}
+	progress.status.SetString(fmt.Sprintf("%v: %v", progress.label, status))
+	progress.terminal.EndLine()
+	progress.terminal.Announce(message)
+
+	progress.terminal.SwapBuffer(progress.previous)
+	progress.previous.ClearPrevious()
+	progress.terminal.Draw()
+	progress.started = false
+}
+
+// Whole percentage of the work done, or -1 if the total is unknown
+func (progress Progress) percent() int {
+	if progress.total <= 0 {
+		return -1
+	}
+	percent := progress.current * 100 / progress.total
+	if percent > 100 {
+		return 100
+	}
+	if percent < 0 {
+		return 0
+	}
+	return percent
+}
+
+// Number of percentage steps completed
+func (progress Progress) milestone() int {
+	if progress.percentStep <= 0 || progress.total <= 0 {
+		return 0
+	}
+	return progress.percent() / progress.percentStep
+}
+
+func (progress Progress) statusText() string {
+	if progress.total <= 0 {
+		return fmt.Sprintf("%v: %v done", progress.label, progress.current)
+	}
+	return fmt.Sprintf(
+		"%v: %v%% (%v of %v)",
+		progress.label,
+		progress.percent(),
+		progress.current,
+		progress.total,
+	)
+}
+
+func (progress Progress) spokenProgress() string {
+	if progress.total <= 0 {
+		return fmt.Sprintf("%v, %v done", progress.label, progress.current)
+	}
+	return fmt.Sprintf("%v, %v percent", progress.label, progress.percent())
+}
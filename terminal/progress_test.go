@@ -0,0 +1,158 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/window"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	current time.Time
+}
+
+func (clock *testClock) now() time.Time {
+	return clock.current
+}
+
+func TestProgressAnnouncements(t *testing.T) {
+	type update struct {
+		current int
+		elapsed time.Duration
+	}
+
+	trials := []struct {
+		description string
+		total       int
+		percentStep int
+		interval    time.Duration
+		updates     []update
+		failure     error
+		expected    []string
+	}{
+		{
+			description: "Percentage milestones",
+			total:       200,
+			percentStep: 25,
+			updates: []update{
+				{current: 20}, {current: 50}, {current: 60}, {current: 150},
+			},
+			expected: []string{
+				"copy started",
+				"copy, 25 percent",
+				"copy, 75 percent",
+				"copy complete",
+			},
+		},
+		{
+			description: "Reaching the total is left to completion",
+			total:       10,
+			percentStep: 50,
+			updates:     []update{{current: 5}, {current: 10}},
+			expected: []string{
+				"copy started",
+				"copy, 50 percent",
+				"copy complete",
+			},
+		},
+		{
+			description: "Time milestones",
+			total:       100,
+			interval:    30 * time.Second,
+			updates: []update{
+				{current: 1, elapsed: 10 * time.Second},
+				{current: 2, elapsed: 20 * time.Second},
+				{current: 3, elapsed: 10 * time.Second},
+				{current: 4, elapsed: 10 * time.Second},
+			},
+			expected: []string{
+				"copy started",
+				"copy, 2 percent",
+				"copy complete",
+			},
+		},
+		{
+			description: "Unknown total",
+			total:       0,
+			percentStep: 10,
+			interval:    time.Minute,
+			updates: []update{
+				{current: 5, elapsed: 59 * time.Second},
+				{current: 9, elapsed: time.Second},
+			},
+			expected: []string{
+				"copy started",
+				"copy, 9 done",
+				"copy complete",
+			},
+		},
+		{
+			description: "Failure",
+			total:       10,
+			percentStep: 50,
+			updates:     []update{{current: 5}},
+			failure:     errors.New("disk full"),
+			expected: []string{
+				"copy started",
+				"copy, 50 percent",
+				"copy failed, disk full",
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			terminalUnderTest, _, announcer := newTestTerminal(&buf, 20, 40)
+
+			clock := testClock{current: time.Unix(0, 0)}
+			progress := terminalUnderTest.NewProgress("copy", trial.total).
+				SetMilestones(trial.percentStep, trial.interval).
+				SetClock(clock.now)
+			progress.Start()
+			for _, next := range trial.updates {
+				clock.current = clock.current.Add(next.elapsed)
+				progress.Update(next.current)
+			}
+			if trial.failure != nil {
+				progress.Fail(trial.failure)
+			} else {
+				progress.Complete()
+			}
+
+			assert.Equal(tt, trial.expected, announcer.Messages)
+			assert.Equal(tt, &buf, terminalUnderTest.CurrentBuffer())
+		})
+	}
+}
+
+func TestProgressStatusLine(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ")
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	progress := terminalUnderTest.NewProgress("copy", 10)
+	progress.Start()
+	progress.Update(5)
+	progress.Complete()
+
+	assert.Equal(
+		t,
+		fmtLine(
+			left(2), clearForward(), "copy: 0% (0 of 10)",
+			left(12), "50% (5 of 10)",
+			left(13), clearCursorForward(), "done",
+			"\n", "> ",
+		),
+		string(file.written),
+	)
+}
+
+func clearForward() string {
+	return fmtLine(window.CSI, window.CURSOR_FORWARD, "J")
+}
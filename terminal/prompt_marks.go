@@ -0,0 +1,51 @@
+package terminal
+
+import "github.com/bekreth/screen_reader_terminal/buffer"
+
+// The first time a buffer is drawn at a new prompt, draws the prefix alone between the
+// prompt start and input start marks, so the rest of Draw adds the input after them
+func (terminal *Terminal) markPrompt() {
+	if !terminal.settings.PromptMarks || terminal.promptMarked {
+		return
+	}
+	if previousData, _ := terminal.buffer.PreviousOutput(); previousData != "" {
+		return
+	}
+	terminal.promptMarked = true
+
+	value, position := terminal.buffer.OutputWithoutPrefix()
+	values := buffer.BufferValues{
+		Prefix:             terminal.buffer.GetPrefix(),
+		ContinuationPrefix: terminal.buffer.GetContinuationPrefix(),
+	}
+	terminal.window.MarkPromptStart()
+	terminal.buffer.SetCurrentValues(values)
+	terminal.Draw()
+
+	values.Value = value
+	values.Position = position
+	terminal.buffer.SetCurrentValues(values)
+	terminal.window.MarkInputStart()
+}
+
+// Marks the start of the output of the command just entered
+func (terminal *Terminal) markOutput() {
+	terminal.promptMarked = false
+	if terminal.settings.PromptMarks {
+		terminal.window.MarkOutputStart()
+	}
+}
+
+// Marks the end of the output of the command entered at the last prompt, along with
+// its exit code.  The buffer is drawn again after the mark, as it is usually already
+// on screen below the command's output.
+func (terminal *Terminal) CommandFinished(exitCode int) {
+	if !terminal.settings.PromptMarks {
+		return
+	}
+	terminal.eraseBuffer()
+	terminal.window.MarkCommandFinished(exitCode)
+	terminal.promptMarked = false
+	terminal.buffer.ClearPrevious()
+	terminal.Draw()
+}
@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func mark(kind string) string {
+	return fmtLine(window.OSC, "133;", kind, window.ST)
+}
+
+func TestPromptMarks(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		expectedOutput string
+	}{
+		{
+			description: "Marks off by default",
+			settings:    Settings{},
+			expectedOutput: fmtLine(
+				"> ", "ls", "\n",
+				"\n", "a\n", "> ",
+			),
+		},
+		{
+			description: "Marks around prompt, input and output",
+			settings:    Settings{PromptMarks: true},
+			expectedOutput: fmtLine(
+				mark("A"), "> ", mark("B"), "ls", "\n", mark("C"),
+				"\n", "a\n", mark("A"), "> ", mark("B"),
+				left(2), clearForward(), mark("D;2"), mark("A"), "> ", mark("B"),
+			),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ")
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetSettings(trial.settings)
+
+			terminalUnderTest.Draw()
+			buf.AddString("ls")
+			terminalUnderTest.Draw()
+			terminalUnderTest.NewLine()
+			terminalUnderTest.PrintLines("a")
+			terminalUnderTest.CommandFinished(2)
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package terminal
+
+import "github.com/bekreth/screen_reader_terminal/utils"
+
+// Tells the user their input was rejected, with the invalid key cue or in speech
+func (terminal *Terminal) RejectInput() {
+	if terminal.settings.SpeakRejections {
+		terminal.Announce("not allowed")
+		return
+	}
+	terminal.Cue(utils.INVALID_KEY)
+}
+
+// Adds a character and draws it, returning false if the constraints reject it
+func (terminal *Terminal) AddCharacter(character rune) bool {
+	if !terminal.buffer.AddCharacter(character) {
+		terminal.RejectInput()
+		return false
+	}
+	terminal.Draw()
+	return true
+}
@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectInput(t *testing.T) {
+	trials := []struct {
+		description      string
+		settings         Settings
+		edit             func(*Terminal)
+		expectedValue    string
+		expectedOutput   string
+		expectedMessages []string
+	}{
+		{
+			description:    "Accepted character is drawn",
+			edit:           func(terminal *Terminal) { terminal.AddCharacter('7') },
+			expectedValue:  "807",
+			expectedOutput: "7",
+		},
+		{
+			description:    "Rejected character rings the bell",
+			edit:           func(terminal *Terminal) { terminal.AddCharacter('x') },
+			expectedValue:  "80",
+			expectedOutput: "\a",
+		},
+		{
+			description:      "Rejected character is spoken",
+			settings:         Settings{SpeakRejections: true},
+			edit:             func(terminal *Terminal) { terminal.AddCharacter('x') },
+			expectedValue:    "80",
+			expectedOutput:   "",
+			expectedMessages: []string{"not allowed"},
+		},
+		{
+			description:    "Paste past the maximum length is rejected whole",
+			edit:           func(terminal *Terminal) { terminal.Paste("8080") },
+			expectedValue:  "80",
+			expectedOutput: "\a",
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString("80")
+			buf.SetPrefix("Port: ").SetAllowed("0123456789").SetMaxLength(5)
+			terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetSettings(trial.settings)
+			terminalUnderTest.Draw()
+			file.written = []byte{}
+
+			trial.edit(terminalUnderTest)
+
+			value, _ := buf.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedValue, value)
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+			assert.Equal(tt, trial.expectedMessages, announcer.Messages)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/bekreth/screen_reader_terminal/window"
+)
+
+// Reads out the right prompt, which is how it is heard when RightPromptOnRequest keeps
+// it off screen
+func (terminal *Terminal) SpeakRightPrompt() {
+	text := style.Text(terminal.buffer.GetRightPrompt()...)
+	if text == "" {
+		terminal.Announce("no right prompt")
+		return
+	}
+	terminal.Announce(text)
+}
+
+// Draws the buffer again for a new window size, such as after the window is resized,
+// so the right prompt moves to the new right edge
+func (terminal *Terminal) Resize(size window.WindowSize) {
+	terminal.eraseBuffer()
+	terminal.window = terminal.window.SetWindowSize(size)
+	terminal.buffer.ClearPrevious()
+	terminal.Draw()
+}
+
+// The right prompt if it fits on the first row, leaving at least a space after the
+// input, or nil if it does not.  The last column is left empty so the cursor never
+// waits to wrap after the prompt is written.
+func (terminal Terminal) fitRightPrompt(rows []string) []style.Span {
+	spans := terminal.buffer.GetRightPrompt()
+	if len(rows) == 0 ||
+		terminal.settings.RightPromptOnRequest ||
+		style.Text(spans...) == "" {
+		return nil
+	}
+	if utils.DisplayWidth(rows[0]) >= terminal.rightPromptStart(spans) {
+		return nil
+	}
+	return spans
+}
+
+// The column the right prompt starts at
+func (terminal Terminal) rightPromptStart(spans []style.Span) int {
+	return terminal.window.GetWindowSize().Width - 1 - utils.DisplayWidth(style.Text(spans...))
+}
+
+// Pads the first row out to the right prompt and adds it
+func (terminal Terminal) withRightPrompt(rows []string, spans []style.Span) []string {
+	if len(rows) == 0 || spans == nil {
+		return rows
+	}
+	padding := terminal.rightPromptPadding(rows[0], spans)
+	rows[0] = rows[0] + strings.Repeat(" ", padding) + style.Text(spans...)
+	return rows
+}
+
+// The spaces between the first row and the right prompt
+func (terminal Terminal) rightPromptPadding(row string, spans []style.Span) int {
+	return utils.IntMax(terminal.rightPromptStart(spans)-utils.DisplayWidth(row), 0)
+}
+
+func firstRow(rows []string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	return rows[0]
+}
+
+// The styles from the start of a row onwards, with the first row's styles covering the
+// padding and the right prompt when it is shown
+func (terminal Terminal) rowStyles(
+	styles []style.Style,
+	rowStarts []int,
+	row int,
+	firstRow string,
+	rightPrompt []style.Span,
+) []style.Style {
+	rowStyles := stylesFromRow(styles, rowStarts, row)
+	if row != 0 || rightPrompt == nil {
+		return rowStyles
+	}
+	// Styles are by byte, so the right prompt starts after the bytes of the row
+	start := len(firstRow) + terminal.rightPromptPadding(firstRow, rightPrompt)
+	output := make([]style.Style, start+len(style.Text(rightPrompt...)))
+	copy(output, rowStyles[:utils.IntMin(len(firstRow), len(rowStyles))])
+	if !terminal.settings.DisableStyles {
+		copy(output[start:], style.Styles(rightPrompt...))
+	}
+	return output
+}
+
+func sameSpans(previous []style.Span, current []style.Span) bool {
+	if len(previous) != len(current) || (previous == nil) != (current == nil) {
+		return false
+	}
+	for i := range previous {
+		if previous[i] != current[i] {
+			return false
+		}
+	}
+	return true
+}
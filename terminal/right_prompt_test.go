@@ -0,0 +1,137 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/window"
+	"github.com/stretchr/testify/assert"
+)
+
+func spaces(count int) string {
+	return strings.Repeat(" ", count)
+}
+
+func TestRightPrompt(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		typed          string
+		expectedOutput string
+	}{
+		{
+			description: "Drawn at the right edge and kept as the input grows",
+			settings:    Settings{},
+			typed:       "ab",
+			expectedOutput: fmtLine(
+				"> ", spaces(13), "main", left(17),
+				"a", spaces(12), "main", left(16),
+				"b", spaces(11), "main", left(15),
+			),
+		},
+		{
+			description: "Hidden once the input reaches it",
+			settings:    Settings{},
+			typed:       "abcdefghijklm",
+			expectedOutput: fmtLine(
+				"> ", spaces(13), "main", left(17),
+				"abcdefghijkl", " ", "main", left(5),
+				clearCursorForward(), "m",
+			),
+		},
+		{
+			description:    "Off screen when spoken on request",
+			settings:       Settings{RightPromptOnRequest: true},
+			typed:          "a",
+			expectedOutput: fmtLine("> ", "a"),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ").SetRightPrompt(style.Plain("main"))
+			terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 20)
+			terminalUnderTest.SetSettings(trial.settings)
+
+			terminalUnderTest.Draw()
+			if len(trial.typed) > 2 {
+				buf.AddString(trial.typed[:len(trial.typed)-1])
+				terminalUnderTest.Draw()
+				buf.AddString(trial.typed[len(trial.typed)-1:])
+				terminalUnderTest.Draw()
+			} else {
+				for _, character := range trial.typed {
+					buf.AddCharacter(character)
+					terminalUnderTest.Draw()
+				}
+			}
+			terminalUnderTest.SpeakRightPrompt()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+			assert.Equal(tt, []string{"main"}, announcer.Messages)
+		})
+	}
+}
+
+func TestRightPromptMovesOnResize(t *testing.T) {
+	buf := buffer.NewBufferWithString("ls")
+	buf.SetPrefix("> ").SetRightPrompt(style.Bold("0"))
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	terminalUnderTest.Resize(window.WindowSize{Height: 20, Width: 10})
+
+	assert.Equal(
+		t,
+		fmtLine(left(4), clearForward(), "> ls", spaces(4), bold("0"), left(5)),
+		string(file.written),
+	)
+}
+
+func TestRightPromptWithSuggestion(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ").SetRightPrompt(style.Plain("main"))
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetSettings(Settings{DisableStyles: true}).
+		SetSuggester(completion.NewWordListCompleter("abcdefghijklmnop"))
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	buf.AddCharacter('a')
+	terminalUnderTest.Draw()
+	buf.AddCharacter('b')
+	terminalUnderTest.Draw()
+
+	assert.Equal(
+		t,
+		fmtLine(
+			"a", spaces(12), "main", left(16), "bcdefghijkl", left(11),
+			clearCursorForward(), "b", spaces(11), "main", left(15), "cdefghijkl", left(10),
+		),
+		string(file.written),
+	)
+}
+
+func TestRightPromptMeasuredInColumns(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ").SetRightPrompt(style.Bold("日本"))
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 20)
+
+	terminalUnderTest.Draw()
+	buf.AddString("é")
+	terminalUnderTest.Draw()
+
+	assert.Equal(
+		t,
+		fmtLine(
+			"> ", spaces(13), bold("日本"), left(17),
+			"é", spaces(12), bold("日本"), left(16),
+		),
+		string(file.written),
+	)
+}
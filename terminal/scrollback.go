@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/history"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+const DEFAULT_SCROLLBACK_SIZE = 1000
+
+// Sets how many lines of output are kept for review, discarding those already kept
+func (terminal *Terminal) SetScrollbackSize(size int) *Terminal {
+	terminal.scrollback = history.NewScrollback(size)
+	return terminal
+}
+
+// Keeps the lines of the buffer as they were left on screen
+func (terminal *Terminal) recordOutput() {
+	output, _ := terminal.displayOutput()
+	terminal.recordLines(output)
+}
+
+func (terminal *Terminal) recordLines(lines ...string) {
+	for _, line := range lines {
+		terminal.scrollback.Add(strings.Split(line, "\n")...)
+	}
+}
+
+// Steps through the output kept in the scroll back, reading each line out in full,
+// without touching the buffer being edited.  Review starts on the most recent line.
+//
+// The up and down arrows, or k and j, move a line at a time, home and end, or g and G,
+// move to the oldest and newest lines, and c moves to the start of the last command's
+// output.  Escape, q or enter returns to the buffer.
+func (terminal *Terminal) ReviewScrollback(reader input.KeyReader) error {
+	count := terminal.scrollback.Len()
+	if count == 0 {
+		terminal.Announce("nothing to review")
+		return nil
+	}
+
+	review := buffer.NewBuffer()
+	review.SetPrefix("review: ")
+	previous := terminal.ReplaceBuffer(&review)
+	defer func() {
+		terminal.ReplaceBuffer(previous)
+		terminal.Announce("back to input")
+	}()
+
+	current := count - 1
+	show := func(line int) {
+		current = line
+		review.SetString(terminal.scrollback.Line(line))
+		terminal.Draw()
+		terminal.Announce(spokenLine(terminal.scrollback.Line(line)))
+	}
+	terminal.Announce(fmt.Sprintf("review, %v lines", count))
+	show(current)
+
+	for {
+		key, err := reader.ReadKey()
+		if err != nil {
+			return err
+		}
+		switch {
+		case key.Code == input.ESCAPE || key.Code == input.ENTER,
+			key.Code == input.RUNE && key.Rune == 'q':
+			return nil
+		case key.Code == input.UP,
+			key.Code == input.RUNE && key.Rune == 'k':
+			if current == 0 {
+				terminal.Cue(utils.BUFFER_START)
+				terminal.Announce("top")
+			} else {
+				show(current - 1)
+			}
+		case key.Code == input.DOWN,
+			key.Code == input.RUNE && key.Rune == 'j':
+			if current == count-1 {
+				terminal.Cue(utils.BUFFER_END)
+				terminal.Announce("bottom")
+			} else {
+				show(current + 1)
+			}
+		case key.Code == input.HOME,
+			key.Code == input.RUNE && key.Rune == 'g':
+			show(0)
+		case key.Code == input.END,
+			key.Code == input.RUNE && key.Rune == 'G':
+			show(count - 1)
+		case key.Code == input.RUNE && key.Rune == 'c':
+			start := terminal.scrollback.CommandStart()
+			if start >= count {
+				terminal.Announce("no output from last command")
+			} else {
+				show(start)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewScrollback(t *testing.T) {
+	up := input.Key{Code: input.UP}
+	down := input.Key{Code: input.DOWN}
+
+	trials := []struct {
+		description      string
+		scrollbackSize   int
+		keys             []input.Key
+		expectedMessages []string
+	}{
+		{
+			description:    "Stepping through lines",
+			scrollbackSize: DEFAULT_SCROLLBACK_SIZE,
+			keys:           []input.Key{up, up, up, up, down, input.RuneKey('q')},
+			expectedMessages: []string{
+				"review, 4 lines",
+				"b",
+				"a",
+				"> ls",
+				"> pwd",
+				"top",
+				"> ls",
+				"back to input",
+			},
+		},
+		{
+			description:    "Jumping to the last command's output",
+			scrollbackSize: DEFAULT_SCROLLBACK_SIZE,
+			keys:           []input.Key{input.RuneKey('g'), input.RuneKey('c'), down, down},
+			expectedMessages: []string{
+				"review, 4 lines",
+				"b",
+				"> pwd",
+				"a",
+				"b",
+				"bottom",
+				"back to input",
+			},
+		},
+		{
+			description:    "Oldest lines dropped once full",
+			scrollbackSize: 2,
+			keys:           []input.Key{up, up, input.RuneKey('c'), {Code: input.ESCAPE}},
+			expectedMessages: []string{
+				"review, 2 lines",
+				"b",
+				"a",
+				"top",
+				"a",
+				"back to input",
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ")
+			terminalUnderTest, _, announcer := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetScrollbackSize(trial.scrollbackSize)
+
+			buf.AddString("pwd")
+			terminalUnderTest.Draw()
+			terminalUnderTest.NewLine()
+			buf.AddString("ls")
+			terminalUnderTest.Draw()
+			terminalUnderTest.NewLine()
+			terminalUnderTest.PrintLines("a", "b")
+			buf.AddString("ca")
+			terminalUnderTest.Draw()
+
+			reader := input.TestReader{Keys: trial.keys}
+			terminalUnderTest.ReviewScrollback(&reader)
+
+			assert.Equal(tt, trial.expectedMessages, announcer.Messages)
+			assert.Equal(tt, &buf, terminalUnderTest.CurrentBuffer())
+			value, position := buf.OutputWithoutPrefix()
+			assert.Equal(tt, "ca", value)
+			assert.Equal(tt, 2, position)
+		})
+	}
+}
+
+func TestReviewEmptyScrollback(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, _, announcer := newTestTerminal(&buf, 20, 40)
+
+	reader := input.TestReader{}
+	err := terminalUnderTest.ReviewScrollback(&reader)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nothing to review"}, announcer.Messages)
+}
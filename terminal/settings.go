@@ -0,0 +1,48 @@
+package terminal
+
+import "os"
+
+// Settings changes how everything drawn through the terminal is presented
+type Settings struct {
+	// Lays output out to be read in speech rather than scanned by eye, such as reading
+	// tables out row by row instead of aligning them in columns
+	ScreenReaderFirst bool
+
+	// Emits OSC 133 shell integration marks around the prompt, the input and the output
+	// of each command, for terminals that can jump between commands
+	PromptMarks bool
+
+	// Drops colour from styled text while keeping bold and underline, as asked for by
+	// the NO_COLOR environment variable
+	NoColor bool
+	// Draws styled text as plain text, so no escape codes reach braille displays
+	DisableStyles bool
+
+	// Leaves the right prompt off screen, so it is only heard through SpeakRightPrompt
+	// rather than read out again as the first row is redrawn
+	RightPromptOnRequest bool
+
+	// Says "not allowed" when typing is rejected by the buffer's constraints, rather
+	// than playing the invalid key cue
+	SpeakRejections bool
+
+	// How control characters typed or pasted into the buffer are drawn, which is in
+	// caret notation unless changed
+	Controls ControlPolicy
+}
+
+// The settings a terminal starts with, taking NoColor from the environment
+func EnvironmentSettings() Settings {
+	return Settings{
+		NoColor: os.Getenv("NO_COLOR") != "",
+	}
+}
+
+func (terminal *Terminal) SetSettings(settings Settings) *Terminal {
+	terminal.settings = settings
+	return terminal
+}
+
+func (terminal Terminal) Settings() Settings {
+	return terminal.settings
+}
@@ -0,0 +1,43 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentSettings(t *testing.T) {
+	trials := []struct {
+		description string
+		noColor     string
+		expected    Settings
+	}{
+		{
+			description: "NO_COLOR unset",
+			noColor:     "",
+			expected:    Settings{},
+		},
+		{
+			description: "NO_COLOR set",
+			noColor:     "1",
+			expected:    Settings{NoColor: true},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			tt.Setenv("NO_COLOR", trial.noColor)
+			assert.Equal(tt, trial.expected, EnvironmentSettings())
+		})
+	}
+}
+
+func TestNewTerminalHonoursNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	buf := buffer.NewBuffer()
+
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+
+	assert.True(t, terminalUnderTest.Settings().NoColor)
+}
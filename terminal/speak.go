@@ -0,0 +1,89 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reads out the whole buffer as drawn, prefix included
+func (terminal *Terminal) SpeakBuffer() {
+	output, _ := terminal.buffer.Output()
+	terminal.Announce(spokenLine(strings.ReplaceAll(output, "\n", ", new line, ")))
+}
+
+// Reads out the word under the cursor, or the word just before it if the cursor is on
+// the space after a word
+func (terminal *Terminal) SpeakWord() {
+	terminal.Announce(spokenLine(terminal.wordAtCursor()))
+}
+
+// Reads out the word under the cursor a letter at a time
+func (terminal *Terminal) SpellWord() {
+	word := terminal.wordAtCursor()
+	if word == "" {
+		terminal.Announce("blank")
+		return
+	}
+	letters := []string{}
+	for _, letter := range word {
+		if unicode.IsUpper(letter) {
+			letters = append(letters, "cap "+string(letter))
+		} else {
+			letters = append(letters, string(letter))
+		}
+	}
+	terminal.Announce(strings.Join(letters, " "))
+}
+
+// Reads out the line and column of the cursor, counting from 1 and including the
+// prefix, so it matches where the cursor sits on screen
+func (terminal *Terminal) SpeakPosition() {
+	output, cursor := terminal.buffer.Output()
+	cursor = clampCursor(output, cursor)
+	before := output[:cursor]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	terminal.Announce(fmt.Sprintf(
+		"line %v of %v, column %v",
+		line,
+		strings.Count(output, "\n")+1,
+		column,
+	))
+}
+
+func (terminal Terminal) wordAtCursor() string {
+	output, cursor := terminal.buffer.Output()
+	cursor = clampCursor(output, cursor)
+
+	// Scanning back from the cursor as well as forward picks up the word before the
+	// cursor when the cursor is on the space following it
+	start := cursor
+	end := cursor
+	for start > 0 {
+		character, size := utf8.DecodeLastRuneInString(output[:start])
+		if unicode.IsSpace(character) {
+			break
+		}
+		start -= size
+	}
+	for end < len(output) {
+		character, size := utf8.DecodeRuneInString(output[end:])
+		if unicode.IsSpace(character) {
+			break
+		}
+		end += size
+	}
+	return output[start:end]
+}
+
+func clampCursor(output string, cursor int) int {
+	if cursor < 0 {
+		return 0
+	}
+	if cursor > len(output) {
+		return len(output)
+	}
+	return cursor
+}
@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeakCommands(t *testing.T) {
+	trials := []struct {
+		description      string
+		value            string
+		cursor           int
+		speak            func(*Terminal)
+		expectedMessages []string
+	}{
+		{
+			description:      "Whole buffer",
+			value:            "echo hi\nthere",
+			cursor:           0,
+			speak:            (*Terminal).SpeakBuffer,
+			expectedMessages: []string{"> echo hi, new line, there"},
+		},
+		{
+			description:      "Word under the cursor",
+			value:            "git commit --amend",
+			cursor:           6,
+			speak:            (*Terminal).SpeakWord,
+			expectedMessages: []string{"commit"},
+		},
+		{
+			description:      "Word before the cursor",
+			value:            "git commit",
+			cursor:           10,
+			speak:            (*Terminal).SpeakWord,
+			expectedMessages: []string{"commit"},
+		},
+		{
+			description:      "No word at the cursor",
+			value:            "git  commit",
+			cursor:           4,
+			speak:            (*Terminal).SpeakWord,
+			expectedMessages: []string{"blank"},
+		},
+		{
+			description:      "Spelling a word",
+			value:            "cd Docs",
+			cursor:           4,
+			speak:            (*Terminal).SpellWord,
+			expectedMessages: []string{"cap D o c s"},
+		},
+		{
+			description:      "Position on the first line counts the prefix",
+			value:            "ls -la",
+			cursor:           3,
+			speak:            (*Terminal).SpeakPosition,
+			expectedMessages: []string{"line 1 of 1, column 6"},
+		},
+		{
+			description:      "Position on a later line",
+			value:            "for x\ndone",
+			cursor:           8,
+			speak:            (*Terminal).SpeakPosition,
+			expectedMessages: []string{"line 2 of 2, column 3"},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString(trial.value)
+			buf.SetPrefix("> ").SetCursor(trial.cursor)
+			terminalUnderTest, _, announcer := newTestTerminal(&buf, 20, 40)
+
+			trial.speak(terminalUnderTest)
+
+			assert.Equal(tt, trial.expectedMessages, announcer.Messages)
+		})
+	}
+}
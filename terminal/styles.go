@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+func (terminal *Terminal) SetHighlighter(highlighter style.Highlighter) *Terminal {
+	terminal.highlighter = highlighter
+	return terminal
+}
+
+// The style of each byte of the buffer's output as drawn, or nil if it is unstyled
+func (terminal Terminal) outputStyles() []style.Style {
+	styles := terminal.bufferStyles()
+	if styles == nil {
+		return nil
+	}
+	data, _ := terminal.buffer.Output()
+	_, indices := terminal.settings.Controls.sanitise(data)
+	return sanitisedStyles(styles, indices)
+}
+
+// The style of each byte of the buffer's output before controls are replaced
+func (terminal Terminal) bufferStyles() []style.Style {
+	if terminal.settings.DisableStyles {
+		return nil
+	}
+	prefixStyles := terminal.buffer.PrefixStyles()
+	valueStyles := terminal.highlight()
+	if valueStyles == nil {
+		return prefixStyles
+	}
+
+	prefix := terminal.buffer.GetPrefix()
+	continuationPrefix := terminal.buffer.GetContinuationPrefix()
+	value, _ := terminal.buffer.OutputWithoutPrefix()
+
+	output := make([]style.Style, len(prefix), len(prefix)+len(value))
+	copy(output, prefixStyles)
+	for i, valueStyle := range valueStyles {
+		output = append(output, valueStyle)
+		if value[i] == '\n' {
+			output = append(output, make([]style.Style, len(continuationPrefix))...)
+		}
+	}
+	return output
+}
+
+// The style of each byte of the buffer's value from the highlighter, or nil for none
+func (terminal Terminal) highlight() []style.Style {
+	if terminal.highlighter == nil ||
+		terminal.settings.ScreenReaderFirst ||
+		terminal.buffer.IsMasked() {
+		return nil
+	}
+	value, _ := terminal.buffer.OutputWithoutPrefix()
+	if value == "" {
+		return nil
+	}
+	spans := terminal.highlighter.Highlight(value)
+	if style.Text(spans...) != value {
+		return nil
+	}
+	return style.Styles(spans...)
+}
+
+// The styles from the start of a row onwards
+func stylesFromRow(styles []style.Style, rowStarts []int, row int) []style.Style {
+	if row >= len(rowStarts) {
+		return nil
+	}
+	return stylesFrom(styles, rowStarts[row])
+}
+
+func stylesFrom(styles []style.Style, start int) []style.Style {
+	if start >= len(styles) {
+		return nil
+	}
+	return styles[start:]
+}
+
+func styleAt(styles []style.Style, index int) style.Style {
+	if index >= len(styles) {
+		return style.Style{}
+	}
+	return styles[index]
+}
+
+// The first of the length bytes whose style differs, or -1 if they all match
+func firstStyleDifference(previous []style.Style, current []style.Style, length int) int {
+	length = utils.IntMin(length, utils.IntMax(len(previous), len(current)))
+	for i := 0; i < length; i++ {
+		if styleAt(previous, i) != styleAt(current, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameStyles(previous []style.Style, current []style.Style) bool {
+	return firstStyleDifference(
+		previous,
+		current,
+		utils.IntMax(len(previous), len(current)),
+	) < 0
+}
@@ -0,0 +1,134 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/stretchr/testify/assert"
+)
+
+func bold(input string) string {
+	return fmtLine(style.CSI, "1m", input, style.RESET)
+}
+
+func TestStyledPrefix(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		expectedOutput string
+	}{
+		{
+			description: "Styling drawn around the prefix only",
+			settings:    Settings{},
+			expectedOutput: fmtLine(
+				bold("db"), fmtLine(style.CSI, "31m", ">", style.RESET), " ", "sel",
+				left(7), down(1), "ect",
+			),
+		},
+		{
+			description: "Colour dropped for NO_COLOR",
+			settings:    Settings{NoColor: true},
+			expectedOutput: fmtLine(
+				bold("db"), "> ", "sel",
+				left(7), down(1), "ect",
+			),
+		},
+		{
+			description: "Styling turned off",
+			settings:    Settings{DisableStyles: true},
+			expectedOutput: fmtLine(
+				"db> ", "sel",
+				left(7), down(1), "ect",
+			),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetStyledPrefix(
+				style.Bold("db"),
+				style.Colored(">", style.RED),
+				style.Plain(" "),
+			)
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 7)
+			terminalUnderTest.SetSettings(trial.settings)
+
+			terminalUnderTest.Draw()
+			buf.AddString("select")
+			terminalUnderTest.Draw()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
+
+func TestRestyledPrefixRedrawn(t *testing.T) {
+	buf := buffer.NewBufferWithString("ls")
+	buf.SetPrefix("$ ")
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	buf.SetStyledPrefix(style.Plain("$"), style.Bold(" "))
+	terminalUnderTest.Draw()
+
+	assert.Equal(t, fmtLine(left(3), bold(" "), "ls"), string(file.written))
+}
+
+func TestPrintStyledLines(t *testing.T) {
+	buf := buffer.NewBuffer()
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+
+	terminalUnderTest.PrintStyledLines(
+		[]style.Span{style.Bold("error"), style.Plain(": missing")},
+	)
+
+	assert.Equal(t, fmtLine("\n", bold("error"), ": missing\n"), string(file.written))
+}
+
+func TestHighlightedInput(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		expectedOutput string
+	}{
+		{
+			description: "Keyword restyled once complete",
+			settings:    Settings{},
+			expectedOutput: fmtLine(
+				"> ", "i", left(1), bold("if"), " ", "i", left(1), bold("if"),
+				left(2), "ifx", left(1), "f",
+			),
+		},
+		{
+			description: "No highlighting for screen readers",
+			settings:    Settings{ScreenReaderFirst: true},
+			expectedOutput: fmtLine(
+				"> ", "i", "f", " ", "i", "f", "x", left(1), "f",
+			),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ")
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetSettings(trial.settings).
+				SetHighlighter(style.NewKeywordHighlighter(style.Style{Bold: true}, "if"))
+
+			terminalUnderTest.Draw()
+			for _, character := range "if ifx" {
+				buf.AddCharacter(character)
+				terminalUnderTest.Draw()
+			}
+			buf.RemoveCharacter()
+			buf.AddCharacter('f')
+			terminalUnderTest.Draw()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
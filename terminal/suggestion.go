@@ -0,0 +1,109 @@
+package terminal
+
+import (
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/bekreth/screen_reader_terminal/window"
+)
+
+var suggestionStyle = style.Style{Faint: true, Foreground: style.BRIGHT_BLACK}
+
+// Suggests the rest of the line from the terminal's history as it is typed
+func (terminal *Terminal) EnableSuggestions() *Terminal {
+	terminal.suggester = terminal.history
+	return terminal
+}
+
+// Suggests the rest of the line as it is typed, or stops suggesting if suggester is nil
+func (terminal *Terminal) SetSuggester(suggester completion.Suggester) *Terminal {
+	terminal.suggester = suggester
+	return terminal
+}
+
+// Adds the suggestion to the buffer, returning false if there is none or it is rejected
+func (terminal *Terminal) AcceptSuggestion() bool {
+	if terminal.suggestion == "" {
+		return false
+	}
+	if !terminal.buffer.AddString(terminal.suggestion) {
+		terminal.RejectInput()
+		return false
+	}
+	terminal.Draw()
+	return true
+}
+
+// Erases the suggestion, which never wraps, by clearing the rest of the row
+func (terminal *Terminal) clearSuggestion() {
+	if terminal.suggestionShown {
+		terminal.window.ClearLine(window.CURSOR_FORWARD)
+		terminal.suggestionShown = false
+		// Clearing the rest of the first row takes the right prompt with it
+		if _, row, _, _ := terminal.determineRows(terminal.displayPreviousOutput()); row == 0 {
+			terminal.drawnRightPrompt = nil
+		}
+	}
+	terminal.suggestion = ""
+}
+
+// Draws the suggestion after the cursor, announcing each new one once
+func (terminal *Terminal) showSuggestion(cursorOffset int, cursorRow int) {
+	suggestion := terminal.suggest()
+	if suggestion == "" {
+		return
+	}
+	available := terminal.window.GetWindowSize().Width - cursorOffset
+	if cursorRow == 0 && terminal.drawnRightPrompt != nil {
+		// Leave a space before the right prompt
+		available = terminal.rightPromptStart(terminal.drawnRightPrompt) - 1 - cursorOffset
+	}
+	sanitised, _ := terminal.settings.Controls.sanitise(suggestion)
+	shown := fitWidth(sanitised, available)
+	if shown == "" {
+		return
+	}
+	value, _ := terminal.buffer.OutputWithoutPrefix()
+	if full := value + suggestion; full != terminal.announcedSuggestion {
+		terminal.announcedSuggestion = full
+		terminal.Announce("suggestion: " + full)
+	}
+	terminal.suggestion = suggestion
+	terminal.suggestionShown = true
+	var styles []style.Style
+	if !terminal.settings.DisableStyles {
+		styles = style.Styles(style.Span{Text: shown, Style: suggestionStyle})
+	}
+	terminal.window.Write([]byte(style.Render(shown, styles, !terminal.settings.NoColor)))
+	terminal.window.MoveCursor(-1*utils.DisplayWidth(shown), 0)
+}
+
+// The suggestion up to the end of its first line, if the cursor ends an unmasked buffer
+func (terminal Terminal) suggest() string {
+	if terminal.suggester == nil || terminal.buffer.IsMasked() {
+		return ""
+	}
+	value, position := terminal.buffer.OutputWithoutPrefix()
+	if value == "" || position != len(value) {
+		return ""
+	}
+	suggestion := terminal.suggester.Suggest(value)
+	if end := strings.IndexByte(suggestion, '\n'); end >= 0 {
+		suggestion = suggestion[:end]
+	}
+	return suggestion
+}
+
+// The start of input taking up no more than width columns
+func fitWidth(input string, width int) string {
+	used := 0
+	for i, character := range input {
+		used += utils.RuneWidth(character)
+		if used > width {
+			return input[:i]
+		}
+	}
+	return input
+}
@@ -0,0 +1,141 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/completion"
+	"github.com/bekreth/screen_reader_terminal/style"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func faint(input string) string {
+	return fmtLine(style.CSI, "2;90m", input, style.RESET)
+}
+
+func TestSuggestionsFromHistory(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ")
+	terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 40)
+	terminalUnderTest.EnableSuggestions()
+
+	buf.AddString("git status")
+	terminalUnderTest.Draw()
+	terminalUnderTest.NewLine()
+	file.written = []byte{}
+
+	buf.AddCharacter('g')
+	terminalUnderTest.Draw()
+	buf.AddCharacter('i')
+	terminalUnderTest.Draw()
+	buf.RetreatCursor(1)
+	terminalUnderTest.Draw()
+	assert.False(t, terminalUnderTest.AcceptSuggestion())
+	buf.AdvanceCursor(1)
+	terminalUnderTest.Draw()
+	assert.True(t, terminalUnderTest.AcceptSuggestion())
+
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "git status", value)
+	assert.Equal(t, []string{"suggestion: git status"}, announcer.Messages)
+	assert.Equal(
+		t,
+		fmtLine(
+			"> g", faint("it status"), left(9),
+			clearCursorForward(), "i", faint("t status"), left(8),
+			clearCursorForward(), left(1),
+			right(1), faint("t status"), left(8),
+			clearCursorForward(), "t status",
+		),
+		string(file.written),
+	)
+}
+
+func TestSuggestionFitsRow(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		expectedOutput string
+	}{
+		{
+			description:    "Cut short at the edge of the window",
+			settings:       Settings{},
+			expectedOutput: fmtLine("abc", faint("def"), left(3)),
+		},
+		{
+			description:    "Plain when styling is off",
+			settings:       Settings{DisableStyles: true},
+			expectedOutput: fmtLine("abc", "def", left(3)),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString("abc")
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 6)
+			terminalUnderTest.SetSettings(trial.settings).
+				SetSuggester(completion.NewWordListCompleter("abcdefgh"))
+
+			terminalUnderTest.Draw()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
+
+func TestSuggestionNeedsRoom(t *testing.T) {
+	trials := []struct {
+		description           string
+		value                 string
+		suggestion            string
+		expectedOutput        string
+		expectedAnnouncements []string
+	}{
+		{
+			description:           "Room measured in columns after wide characters",
+			value:                 "日本",
+			suggestion:            "日本ab",
+			expectedOutput:        fmtLine("日本", "ab", left(2)),
+			expectedAnnouncements: []string{"suggestion: 日本ab"},
+		},
+		{
+			description:           "Not announced when nothing fits",
+			value:                 "abcde",
+			suggestion:            "abcde日",
+			expectedOutput:        "abcde",
+			expectedAnnouncements: nil,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString(trial.value)
+			terminalUnderTest, file, announcer := newTestTerminal(&buf, 20, 6)
+			terminalUnderTest.SetSettings(Settings{DisableStyles: true}).
+				SetSuggester(completion.NewWordListCompleter(trial.suggestion))
+
+			terminalUnderTest.Draw()
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+			assert.Equal(tt, trial.expectedAnnouncements, announcer.Messages)
+		})
+	}
+}
+
+func TestAcceptSuggestionRejected(t *testing.T) {
+	buf := buffer.NewBufferWithString("ab")
+	buf.SetAllowed("ab")
+	player := utils.TestCuePlayer{}
+	terminalUnderTest, _, _ := newTestTerminal(&buf, 20, 20)
+	terminalUnderTest.SetCuePlayer(&player).
+		SetSuggester(completion.NewWordListCompleter("abc"))
+	terminalUnderTest.Draw()
+
+	assert.False(t, terminalUnderTest.AcceptSuggestion())
+
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "ab", value)
+	assert.Equal(t, []utils.Cue{utils.INVALID_KEY}, player.Cues)
+}
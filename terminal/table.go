@@ -0,0 +1,202 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+const (
+	columnSeparator = "  "
+	minColumnWidth  = 2
+)
+
+// Table holds rows of cells under column headers, ready to be printed either as an
+// aligned grid or as a linear description of each row, which reads far better in
+// speech than columns of padded text
+type Table struct {
+	headers []string
+	rows    [][]string
+}
+
+func NewTable(headers ...string) Table {
+	return Table{
+		headers: headers,
+		rows:    [][]string{},
+	}
+}
+
+// Adds a row of cells, in the same order as the headers.  Missing cells are left blank
+// and cells beyond the last header are dropped.
+func (table *Table) AddRow(cells ...string) *Table {
+	row := make([]string, len(table.headers))
+	copy(row, cells)
+	table.rows = append(table.rows, row)
+	return table
+}
+
+// Prints the table below the buffer, laid out as a grid or, for screen reader first
+// terminals, linearly
+func (terminal *Terminal) PrintTable(table Table) {
+	if terminal.settings.ScreenReaderFirst {
+		terminal.PrintLines(table.Linear()...)
+	} else {
+		terminal.PrintLines(table.Grid(terminal.window.GetWindowSize().Width)...)
+	}
+}
+
+// Describes the table and then each row on its own line, such as
+// "row 2: Name is foo, Size is 3 KB"
+func (table Table) Linear() []string {
+	rowCount := fmt.Sprintf("%v rows", len(table.rows))
+	if len(table.rows) == 1 {
+		rowCount = "1 row"
+	}
+	output := []string{fmt.Sprintf(
+		"table, %v, columns: %v",
+		rowCount,
+		strings.Join(table.headers, ", "),
+	)}
+	for i, row := range table.rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			if cell == "" {
+				cell = "blank"
+			}
+			if table.headers[j] == "" {
+				cells[j] = cell
+			} else {
+				cells[j] = fmt.Sprintf("%v is %v", table.headers[j], cell)
+			}
+		}
+		output = append(output, fmt.Sprintf("row %v: %v", i+1, strings.Join(cells, ", ")))
+	}
+	return output
+}
+
+// Lays the table out as aligned columns under a header row.  Column widths are measured
+// in display columns, and when the table would be wider than width the widest columns
+// are narrowed and their cells wrapped, so no line wraps on screen.  A width of zero or
+// less never narrows columns.
+func (table Table) Grid(width int) []string {
+	widths := table.columnWidths(width)
+
+	separators := make([]string, len(widths))
+	for i, columnWidth := range widths {
+		separators[i] = strings.Repeat("-", columnWidth)
+	}
+
+	output := gridRow(table.headers, widths)
+	output = append(output, gridRow(separators, widths)...)
+	for _, row := range table.rows {
+		output = append(output, gridRow(row, widths)...)
+	}
+	return output
+}
+
+func (table Table) columnWidths(width int) []int {
+	widths := make([]int, len(table.headers))
+	for i, header := range table.headers {
+		widths[i] = utils.DisplayWidth(header)
+	}
+	for _, row := range table.rows {
+		for i, cell := range row {
+			widths[i] = utils.IntMax(widths[i], utils.DisplayWidth(cell))
+		}
+	}
+	if width <= 0 {
+		return widths
+	}
+
+	available := width - len(columnSeparator)*(len(widths)-1)
+	total := 0
+	for _, columnWidth := range widths {
+		total += columnWidth
+	}
+	for total > available {
+		widest := 0
+		for i, columnWidth := range widths {
+			if columnWidth > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest] -= 1
+		total -= 1
+	}
+	return widths
+}
+
+// Lays out a single row of cells, which takes as many lines as its most wrapped cell
+func gridRow(cells []string, widths []int) []string {
+	wrapped := make([][]string, len(cells))
+	height := 1
+	for i, cell := range cells {
+		wrapped[i] = wrapCell(cell, widths[i])
+		height = utils.IntMax(height, len(wrapped[i]))
+	}
+
+	output := make([]string, height)
+	for line := range output {
+		parts := make([]string, len(cells))
+		for i := range cells {
+			part := ""
+			if line < len(wrapped[i]) {
+				part = wrapped[i][line]
+			}
+			padding := utils.IntMax(0, widths[i]-utils.DisplayWidth(part))
+			parts[i] = part + strings.Repeat(" ", padding)
+		}
+		output[line] = strings.TrimRight(strings.Join(parts, columnSeparator), " ")
+	}
+	return output
+}
+
+// Splits a cell into lines no wider than width, breaking between words where it can
+func wrapCell(cell string, width int) []string {
+	if utils.DisplayWidth(cell) <= width {
+		return []string{cell}
+	}
+	output := []string{}
+	line := ""
+	for _, word := range strings.Fields(cell) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if utils.DisplayWidth(candidate) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			output = append(output, line)
+		}
+		for utils.DisplayWidth(word) > width {
+			var head string
+			head, word = splitAtWidth(word, width)
+			output = append(output, head)
+		}
+		line = word
+	}
+	if line != "" || len(output) == 0 {
+		output = append(output, line)
+	}
+	return output
+}
+
+// Splits a word after as many characters as fit within width, always taking at least
+// one character
+func splitAtWidth(word string, width int) (string, string) {
+	used := 0
+	for i, character := range word {
+		characterWidth := utils.RuneWidth(character)
+		if used+characterWidth > width && i > 0 {
+			return word[:i], word[i:]
+		}
+		used += characterWidth
+	}
+	return word, ""
+}
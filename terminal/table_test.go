@@ -0,0 +1,158 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableGrid(t *testing.T) {
+	trials := []struct {
+		description    string
+		headers        []string
+		rows           [][]string
+		width          int
+		expectedOutput []string
+	}{
+		{
+			description: "Aligned columns",
+			headers:     []string{"Name", "Size"},
+			rows: [][]string{
+				{"foo", "3 KB"},
+				{"longer name", "12 KB"},
+			},
+			width: 40,
+			expectedOutput: []string{
+				"Name         Size",
+				"-----------  -----",
+				"foo          3 KB",
+				"longer name  12 KB",
+			},
+		},
+		{
+			description: "Missing cells are blank",
+			headers:     []string{"Name", "Size", "Owner"},
+			rows: [][]string{
+				{"foo"},
+			},
+			width: 40,
+			expectedOutput: []string{
+				"Name  Size  Owner",
+				"----  ----  -----",
+				"foo",
+			},
+		},
+		{
+			description: "Wide characters measured by display width",
+			headers:     []string{"Name", "Size"},
+			rows: [][]string{
+				{"日本", "1"},
+			},
+			width: 40,
+			expectedOutput: []string{
+				"Name  Size",
+				"----  ----",
+				"日本  1",
+			},
+		},
+		{
+			description: "Widest column wrapped to fit the width",
+			headers:     []string{"Name", "Note"},
+			rows: [][]string{
+				{"foo", "a rather long note"},
+			},
+			width: 16,
+			expectedOutput: []string{
+				"Name  Note",
+				"----  ----------",
+				"foo   a rather",
+				"      long note",
+			},
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			table := NewTable(trial.headers...)
+			for _, row := range trial.rows {
+				table.AddRow(row...)
+			}
+			assert.Equal(tt, trial.expectedOutput, table.Grid(trial.width))
+		})
+	}
+}
+
+func TestTableLinear(t *testing.T) {
+	table := NewTable("Name", "Size")
+	table.AddRow("foo", "3 KB").AddRow("bar")
+
+	assert.Equal(
+		t,
+		[]string{
+			"table, 2 rows, columns: Name, Size",
+			"row 1: Name is foo, Size is 3 KB",
+			"row 2: Name is bar, Size is blank",
+		},
+		table.Linear(),
+	)
+}
+
+func TestPrintTableFollowsSettings(t *testing.T) {
+	trials := []struct {
+		description    string
+		settings       Settings
+		expectedOutput string
+	}{
+		{
+			description: "Grid by default",
+			settings:    Settings{},
+			expectedOutput: fmtLine(
+				"\n", "Name  Size\n", "----  ----\n", "foo   3 KB\n", "> ",
+			),
+		},
+		{
+			description: "Linear for screen readers",
+			settings:    Settings{ScreenReaderFirst: true},
+			expectedOutput: fmtLine(
+				"\n",
+				"table, 1 row, columns: Name, Size\n",
+				"row 1: Name is foo, Size is 3 KB\n",
+				"> ",
+			),
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBuffer()
+			buf.SetPrefix("> ")
+			terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 40)
+			terminalUnderTest.SetSettings(trial.settings)
+
+			table := NewTable("Name", "Size")
+			table.AddRow("foo", "3 KB")
+			terminalUnderTest.PrintTable(table)
+
+			assert.Equal(tt, trial.expectedOutput, string(file.written))
+		})
+	}
+}
+
+func TestPrintTableWithMultibyteCells(t *testing.T) {
+	buf := buffer.NewBuffer()
+	buf.SetPrefix("> ")
+	terminalUnderTest, file, _ := newTestTerminal(&buf, 20, 12)
+	terminalUnderTest.Draw()
+	file.written = []byte{}
+
+	table := NewTable("Name", "Note")
+	table.AddRow("café", "日本語")
+	terminalUnderTest.PrintTable(table)
+
+	assert.Equal(t, fmtLine(
+		"\n", "Name  Note\n", "----  ------\n", "café  日本語\n", "> ",
+	), string(file.written))
+	// Each row fills no more than the width, so takes up a single row on screen
+	assert.Equal(t, 4, terminalUnderTest.cursorHeight)
+}
@@ -1,8 +1,12 @@
 package terminal
 
 import (
+	"fmt"
+
 	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/completion"
 	"github.com/bekreth/screen_reader_terminal/history"
+	"github.com/bekreth/screen_reader_terminal/style"
 	"github.com/bekreth/screen_reader_terminal/utils"
 	"github.com/bekreth/screen_reader_terminal/window"
 )
@@ -12,7 +16,33 @@ type Terminal struct {
 	window       window.Window
 	buffer       *buffer.Buffer
 	history      *history.History
+	killRing     *buffer.KillRing
 	logger       utils.Logger
+	announcer    utils.Announcer
+	cuePlayer    utils.CuePlayer
+	mutedCues    map[utils.Cue]bool
+
+	settings     Settings
+	promptMarked bool
+	// Styling of each byte of the output last drawn
+	previousStyles []style.Style
+	highlighter    style.Highlighter
+	// The right prompt as last drawn on the first row, or nil if it is not on screen
+	drawnRightPrompt []style.Span
+
+	scrollback history.Scrollback
+
+	inputComplete InputComplete
+
+	completer          completion.Completer
+	completionValue    string
+	completionPosition int
+	completionPending  bool
+
+	suggester           completion.Suggester
+	suggestion          string
+	suggestionShown     bool
+	announcedSuggestion string
 }
 
 func NewTerminal(
@@ -20,7 +50,9 @@ func NewTerminal(
 	buf *buffer.Buffer,
 	logger utils.Logger,
 ) Terminal {
+	scrollback := history.NewScrollback(DEFAULT_SCROLLBACK_SIZE)
 	history := history.NewBufferHistory()
+	killRing := buffer.NewKillRing()
 	win.ClearWindow(window.FULL)
 	win.SetCursorPosition(0, 0)
 	return Terminal{
@@ -28,15 +60,80 @@ func NewTerminal(
 		window:       win,
 		buffer:       buf,
 		history:      &history,
+		killRing:     &killRing,
 		logger:       logger,
+		announcer:    utils.NoOpAnnouncer{},
+		cuePlayer:    utils.BellCuePlayer{Ringer: win},
+		settings:     EnvironmentSettings(),
+		scrollback:   scrollback,
+	}
+}
+
+func (terminal *Terminal) SetAnnouncer(announcer utils.Announcer) *Terminal {
+	terminal.announcer = announcer
+	return terminal
+}
+
+// Sends a message to the screen reader through the terminal's announcer
+func (terminal Terminal) Announce(message string) {
+	if terminal.announcer != nil {
+		terminal.announcer.Announce(message)
 	}
 }
 
 func (terminal *Terminal) AddBuffer(buffer *buffer.Buffer) {
-	terminal.history.AddBuffer(*terminal.buffer)
+	terminal.recordHistory()
 	terminal.buffer = buffer
 }
 
+// Adds the current buffer to history unless it is masked
+func (terminal *Terminal) recordHistory() {
+	if !terminal.buffer.IsMasked() {
+		terminal.history.AddBuffer(*terminal.buffer)
+	}
+}
+
+// Replaces the buffer being edited without recording the old one in history, returning
+// the old buffer so it can be put back
+func (terminal *Terminal) SwapBuffer(buffer *buffer.Buffer) *buffer.Buffer {
+	previous := terminal.buffer
+	terminal.buffer = buffer
+	return previous
+}
+
+// Swaps in another buffer in place of the one on screen, erasing the rows of the old
+// buffer so the new one is drawn over them.  Returns the old buffer.
+func (terminal *Terminal) ReplaceBuffer(buffer *buffer.Buffer) *buffer.Buffer {
+	terminal.eraseBuffer()
+
+	previous := terminal.buffer
+	terminal.buffer = buffer
+	terminal.buffer.ClearPrevious()
+	terminal.Draw()
+	return previous
+}
+
+// Moves the cursor to the start of the buffer as last drawn and clears everything from
+// there down
+func (terminal *Terminal) eraseBuffer() {
+	terminal.clearSuggestion()
+	terminal.drawnRightPrompt = nil
+	previousData, previousCursor := terminal.displayPreviousOutput()
+	previousRows, previousCursorRow, previousCursorOffset, _ := terminal.determineRows(
+		previousData,
+		previousCursor,
+	)
+	terminal.window.MoveCursor(-1*previousCursorOffset, -1*previousCursorRow)
+	terminal.window.ClearWindow(window.CURSOR_FORWARD)
+	if len(previousRows) > 1 {
+		terminal.cursorHeight -= len(previousRows) - 1
+	}
+}
+
+func (terminal Terminal) GetWindowSize() window.WindowSize {
+	return terminal.window.GetWindowSize()
+}
+
 func (terminal Terminal) PreviousBuffer() buffer.Buffer {
 	return terminal.history.GetPrevious()
 }
@@ -54,38 +151,52 @@ func (terminal *Terminal) LoadPreviousBuffer() {
 	terminal.CurrentBuffer().
 		SetString(previousString).
 		SetCursor(previousIndex).
-		SetPrefix(previousBuffer.GetPrefix())
+		SetStyledPrefix(previousBuffer.GetStyledPrefix()...).
+		SetContinuationPrefix(previousBuffer.GetContinuationPrefix())
 }
 
 func (terminal *Terminal) RedrawBuffer() {
 	if terminal.CurrentBuffer().IsEmpty() {
 		terminal.LoadPreviousBuffer()
 		terminal.Draw()
+		terminal.clearSuggestion()
+		terminal.recordOutput()
 		terminal.CurrentBuffer().Clear()
 		terminal.cursorHeight += 1
 		terminal.window.Write([]byte("\n"))
+		terminal.promptMarked = false
 	} else {
+		terminal.clearSuggestion()
+		terminal.recordOutput()
 		terminal.cursorHeight += 1
 		terminal.window.Write([]byte("\n"))
+		terminal.promptMarked = false
 		terminal.buffer.ClearPrevious()
 		terminal.Draw()
 	}
 }
 
 func (terminal *Terminal) Draw() {
+	terminal.clearSuggestion()
+	terminal.markPrompt()
+
 	// Breaking up data from previous render
-	previousData, previousCursor := terminal.buffer.PreviousOutput()
-	previousDataRow, previousCursorRow, previousCursorOffset := terminal.determineRows(
-		previousData,
-		previousCursor,
-	)
+	previousData, previousCursor := terminal.displayPreviousOutput()
+	previousDataRow, previousCursorRow, previousCursorOffset, previousRowStarts :=
+		terminal.determineRows(previousData, previousCursor)
 
 	// Breaking up data from current render
-	currentData, currentCursor := terminal.buffer.Output()
-	currentDataRow, currentCursorRow, currentCursorOffset := terminal.determineRows(
-		currentData,
-		currentCursor,
-	)
+	currentData, currentCursor := terminal.displayOutput()
+	currentDataRow, currentCursorRow, currentCursorOffset, currentRowStarts :=
+		terminal.determineRows(currentData, currentCursor)
+
+	// The right prompt is added to the first row, so the diff shows, updates and hides
+	// it along with the input
+	currentRightPrompt := terminal.fitRightPrompt(currentDataRow)
+	previousFirstRow := firstRow(previousDataRow)
+	currentFirstRow := firstRow(currentDataRow)
+	previousDataRow = terminal.withRightPrompt(previousDataRow, terminal.drawnRightPrompt)
+	currentDataRow = terminal.withRightPrompt(currentDataRow, currentRightPrompt)
 
 	// Splicing together
 	zippedLines := utils.Zip(
@@ -96,11 +207,34 @@ func (terminal *Terminal) Draw() {
 
 	terminal.scrollWindow(len(previousDataRow), len(currentDataRow))
 
+	// Styling is compared separately, so the rows themselves hold only visible text
+	currentStyles := terminal.outputStyles()
+
 	// Calculating delta
 	coords := newCoords(previousCursorOffset, previousCursorRow)
-	if previousData != currentData {
+	if previousData != currentData ||
+		!sameStyles(terminal.previousStyles, currentStyles) ||
+		!sameSpans(terminal.drawnRightPrompt, currentRightPrompt) {
 		for i, dataPair := range zippedLines {
-			rowRequiresUpdate := dataPair.First != dataPair.Second
+			rowStyles := terminal.rowStyles(
+				currentStyles,
+				currentRowStarts,
+				i,
+				currentFirstRow,
+				currentRightPrompt,
+			)
+			styleChange := firstStyleDifference(
+				terminal.rowStyles(
+					terminal.previousStyles,
+					previousRowStarts,
+					i,
+					previousFirstRow,
+					terminal.drawnRightPrompt,
+				),
+				rowStyles,
+				len(dataPair.Second),
+			)
+			rowRequiresUpdate := dataPair.First != dataPair.Second || styleChange >= 0
 			if rowRequiresUpdate {
 				coords = coords.setPendingRow(i)
 				if dataPair.Second == emptyString {
@@ -111,7 +245,13 @@ func (terminal *Terminal) Draw() {
 					terminal.window.ClearLine(window.FULL)
 					continue
 				}
-				coords = terminal.drawRow(dataPair.First, dataPair.Second, coords)
+				coords = terminal.drawStyledRow(
+					dataPair.First,
+					dataPair.Second,
+					coords,
+					rowStyles,
+					styleChange,
+				)
 			}
 		}
 	}
@@ -122,12 +262,45 @@ func (terminal *Terminal) Draw() {
 	coords = coords.applyPendingDeltas()
 
 	terminal.window.MoveCursor(moveX, moveY)
+	terminal.drawnRightPrompt = currentRightPrompt
+	terminal.showSuggestion(currentCursorOffset, currentCursorRow)
+	terminal.announceHiddenInput()
 	terminal.buffer.UpdatePrevious()
+	terminal.previousStyles = currentStyles
 }
 
 func (terminal *Terminal) NewLine() {
+	terminal.clearSuggestion()
+	terminal.announcedSuggestion = ""
 	terminal.cursorHeight += 1
 	terminal.window.Write([]byte("\n"))
-	terminal.history.AddBuffer(*terminal.buffer)
+	terminal.markOutput()
+	terminal.recordHistory()
+	terminal.recordOutput()
+	terminal.scrollback.MarkCommand()
 	terminal.buffer.Clear()
 }
+
+// Moves to a fresh line below the buffer without adding the buffer to history, for
+// prompts whose answers are not commands to be recalled
+func (terminal *Terminal) EndLine() {
+	terminal.buffer.CursorToEnd()
+	terminal.Draw()
+	terminal.clearSuggestion()
+	terminal.recordOutput()
+	terminal.cursorHeight += 1
+	terminal.window.Write([]byte("\n"))
+	terminal.promptMarked = false
+	terminal.buffer.Clear()
+}
+
+// Announces the length of a masked buffer that draws nothing
+func (terminal *Terminal) announceHiddenInput() {
+	if !terminal.buffer.HidesInput() {
+		return
+	}
+	length, changed := terminal.buffer.MaskedLengthChanged()
+	if changed {
+		terminal.Announce(fmt.Sprintf("%v characters", length))
+	}
+}
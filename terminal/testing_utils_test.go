@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/utils"
 	"github.com/bekreth/screen_reader_terminal/window"
 )
 
@@ -18,6 +20,27 @@ func (window *testFile) Write(input []byte) (int, error) {
 	return len(input), nil
 }
 
+// Builds a terminal around buf, with what it wrote on creation cleared
+func newTestTerminal(
+	buf *buffer.Buffer,
+	height int,
+	width int,
+) (*Terminal, *testFile, *utils.TestAnnouncer) {
+	file := testFile{}
+	win := window.NewWindow().
+		SetWriter(&file).
+		SetWindowSize(window.WindowSize{
+			Height: height,
+			Width:  width,
+		})
+	announcer := utils.TestAnnouncer{}
+
+	terminalUnderTest := NewTerminal(win, buf, utils.NoOpLogger{})
+	terminalUnderTest.SetAnnouncer(&announcer)
+	file.written = []byte{}
+	return &terminalUnderTest, &file, &announcer
+}
+
 type testWindow struct {
 	window.Window
 	io.Writer
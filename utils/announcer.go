@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"os/exec"
+)
+
+// Announcer sends a message to the screen reader without drawing it
+type Announcer interface {
+	Announce(message string)
+}
+
+// NoOpAnnouncer matches the Announcer interface and does nothing if no speech is desired
+type NoOpAnnouncer struct {
+}
+
+func (NoOpAnnouncer) Announce(message string) {}
+
+// CommandAnnouncer passes each message as the last argument to a command like spd-say
+type CommandAnnouncer struct {
+	Command   string
+	Arguments []string
+}
+
+func (announcer CommandAnnouncer) Announce(message string) {
+	arguments := append(append([]string{}, announcer.Arguments...), message)
+	go exec.Command(announcer.Command, arguments...).Run()
+}
+
+// TestAnnouncer records every message so tests can check what would have been spoken
+type TestAnnouncer struct {
+	Messages []string
+}
+
+func (announcer *TestAnnouncer) Announce(message string) {
+	announcer.Messages = append(announcer.Messages, message)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import "os/exec"
+
+// Cue is a short sound marking an event, heard alongside speech
+type Cue int
+
+const (
+	// The cursor can go no further back, or there is nothing before it to remove
+	BUFFER_START Cue = 0
+	// The cursor can go no further forward
+	BUFFER_END Cue = 1
+	// A key was pressed that the input does not accept
+	INVALID_KEY Cue = 2
+	// Completion found nothing to offer
+	NO_COMPLETIONS Cue = 3
+)
+
+// CuePlayer plays cues
+type CuePlayer interface {
+	Play(cue Cue)
+}
+
+// NoOpCuePlayer matches the CuePlayer interface and does nothing if no cues are desired
+type NoOpCuePlayer struct {
+}
+
+func (NoOpCuePlayer) Play(cue Cue) {}
+
+// Ringer is anything with a bell to ring, such as a window.Window
+type Ringer interface {
+	Bell()
+}
+
+// BellCuePlayer rings the terminal bell for every cue
+type BellCuePlayer struct {
+	Ringer Ringer
+}
+
+func (player BellCuePlayer) Play(cue Cue) {
+	player.Ringer.Bell()
+}
+
+// CommandCuePlayer passes each cue's sound file as the last argument to a command
+type CommandCuePlayer struct {
+	Command   string
+	Arguments []string
+	Sounds    map[Cue]string
+}
+
+func (player CommandCuePlayer) Play(cue Cue) {
+	sound, found := player.Sounds[cue]
+	if !found {
+		return
+	}
+	arguments := append(append([]string{}, player.Arguments...), sound)
+	go exec.Command(player.Command, arguments...).Run()
+}
+
+// TestCuePlayer records every cue so tests can check what would have been heard
+type TestCuePlayer struct {
+	Cues []Cue
+}
+
+func (player *TestCuePlayer) Play(cue Cue) {
+	player.Cues = append(player.Cues, cue)
+}
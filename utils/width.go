@@ -0,0 +1,45 @@
+package utils
+
+import "unicode"
+
+// Ranges of characters drawn two columns wide, covering the CJK scripts, full width
+// forms and the common emoji blocks
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},
+	{0x2E80, 0x303E},
+	{0x3041, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x3FFFD},
+}
+
+// The number of columns a character takes up when drawn.  Combining marks, format
+// characters and control characters take none.
+func RuneWidth(character rune) int {
+	if unicode.Is(unicode.Mn, character) ||
+		unicode.Is(unicode.Me, character) ||
+		unicode.Is(unicode.Cf, character) ||
+		(unicode.IsControl(character) && character != '\t') {
+		return 0
+	}
+	for _, wide := range wideRanges {
+		if character >= wide[0] && character <= wide[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// The number of columns a string takes up when drawn
+func DisplayWidth(input string) int {
+	width := 0
+	for _, character := range input {
+		width += RuneWidth(character)
+	}
+	return width
+}
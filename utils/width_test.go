@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	trials := []struct {
+		description    string
+		input          string
+		expectedOutput int
+	}{
+		{
+			description:    "Empty string",
+			input:          "",
+			expectedOutput: 0,
+		},
+		{
+			description:    "Ascii",
+			input:          "hello world",
+			expectedOutput: 11,
+		},
+		{
+			description:    "Multi byte but single width",
+			input:          "café",
+			expectedOutput: 4,
+		},
+		{
+			description:    "Combining mark takes no columns",
+			input:          "cafe\u0301",
+			expectedOutput: 4,
+		},
+		{
+			description:    "Wide characters",
+			input:          "日本語",
+			expectedOutput: 6,
+		},
+		{
+			description:    "Emoji",
+			input:          "ok 👍",
+			expectedOutput: 5,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			assert.Equal(tt, trial.expectedOutput, DisplayWidth(trial.input))
+		})
+	}
+}
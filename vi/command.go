@@ -0,0 +1,136 @@
+package vi
+
+import "strconv"
+
+type parseStatus int
+
+const (
+	INCOMPLETE parseStatus = 0
+	COMPLETE   parseStatus = 1
+	INVALID    parseStatus = 2
+)
+
+// A normal mode command, such as 2d3w, broken into its parts.  A zero count means no
+// count was typed.
+type command struct {
+	count       int
+	operator    rune
+	motionCount int
+	motion      rune
+	argument    rune
+	action      rune
+}
+
+func isOperator(key rune) bool {
+	return key == 'd' || key == 'c' || key == 'y'
+}
+
+func isMotion(key rune) bool {
+	switch key {
+	case 'h', 'l', 'w', 'b', 'e', '0', '$', 'f', 't', 'F', 'T':
+		return true
+	}
+	return false
+}
+
+func needsArgument(key rune) bool {
+	return key == 'f' || key == 't' || key == 'F' || key == 'T'
+}
+
+func isAction(key rune) bool {
+	switch key {
+	case 'i', 'a', 'I', 'A', 'x', 'X', 'p', 'P', 'D', 'C', 's', '.':
+		return true
+	}
+	return false
+}
+
+// Reads digits from keys starting at index, with a leading 0 being the 0 motion
+// rather than part of a count
+func parseCount(keys []rune, index int) (int, int) {
+	count := 0
+	for index < len(keys) && keys[index] >= '0' && keys[index] <= '9' {
+		if count == 0 && keys[index] == '0' {
+			break
+		}
+		count = count*10 + int(keys[index]-'0')
+		index += 1
+	}
+	return count, index
+}
+
+// Parses the keys typed so far in normal mode into a command
+func parseCommand(keys []rune) (command, parseStatus) {
+	cmd := command{}
+	index := 0
+
+	cmd.count, index = parseCount(keys, index)
+	if index >= len(keys) {
+		return cmd, INCOMPLETE
+	}
+
+	key := keys[index]
+	index += 1
+	switch {
+	case isAction(key):
+		cmd.action = key
+		return cmd, COMPLETE
+	case isOperator(key):
+		cmd.operator = key
+		cmd.motionCount, index = parseCount(keys, index)
+		if index >= len(keys) {
+			return cmd, INCOMPLETE
+		}
+		key = keys[index]
+		index += 1
+		if key == cmd.operator {
+			cmd.motion = key
+			return cmd, COMPLETE
+		}
+		if !isMotion(key) {
+			return cmd, INVALID
+		}
+	case !isMotion(key):
+		return cmd, INVALID
+	}
+
+	cmd.motion = key
+	if needsArgument(key) {
+		if index >= len(keys) {
+			return cmd, INCOMPLETE
+		}
+		cmd.argument = keys[index]
+	}
+	return cmd, COMPLETE
+}
+
+// The keys of a command with its counts replaced by count
+func withCount(keys []rune, count int) []rune {
+	_, index := parseCount(keys, 0)
+	rest := keys[index:]
+	if len(rest) > 0 && isOperator(rest[0]) {
+		_, motionStart := parseCount(rest, 1)
+		rest = append([]rune{rest[0]}, rest[motionStart:]...)
+	}
+	return append([]rune(strconv.Itoa(count)), rest...)
+}
+
+// The total repetitions of a command, where 2d3w deletes 6 words
+func (cmd command) repetitions() int {
+	count := 1
+	if cmd.count > 0 {
+		count *= cmd.count
+	}
+	if cmd.motionCount > 0 {
+		count *= cmd.motionCount
+	}
+	return count
+}
+
+// Whether running the command changes the buffer and so can be repeated with .
+func (cmd command) isChange() bool {
+	if cmd.operator == 'd' || cmd.operator == 'c' {
+		return true
+	}
+	return cmd.action != 0 && cmd.action != '.'
+}
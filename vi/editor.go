@@ -0,0 +1,332 @@
+package vi
+
+import (
+	"strings"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/utils"
+)
+
+type Mode int
+
+const (
+	INSERT Mode = 0
+	NORMAL Mode = 1
+)
+
+func (mode Mode) String() string {
+	if mode == NORMAL {
+		return "normal mode"
+	}
+	return "insert mode"
+}
+
+const (
+	ESCAPE    rune = 0x1B
+	BACKSPACE rune = 0x7F
+	CONTROL_H rune = 0x08
+)
+
+// Editor layers vi style modal editing on top of a Buffer.  Keys are fed in one at a
+// time through HandleKey, and every change of mode is announced so the screen reader
+// user does not have to rely on the shape of the cursor.
+type Editor struct {
+	buffer    *buffer.Buffer
+	announcer utils.Announcer
+	mode      Mode
+
+	register         string
+	registerLinewise bool
+
+	// Keys of the normal mode command currently being typed
+	pending []rune
+	// Keys of the last change, including any text typed in the insert mode it started
+	lastChange      []rune
+	recordingInsert bool
+	replaying       bool
+}
+
+func NewEditor(buf *buffer.Buffer, announcer utils.Announcer) Editor {
+	return Editor{
+		buffer:    buf,
+		announcer: announcer,
+		mode:      INSERT,
+		pending:   []rune{},
+	}
+}
+
+func (editor *Editor) SetBuffer(buf *buffer.Buffer) *Editor {
+	editor.buffer = buf
+	editor.pending = []rune{}
+	return editor
+}
+
+func (editor Editor) Mode() Mode {
+	return editor.mode
+}
+
+func (editor *Editor) SetMode(mode Mode) {
+	if editor.mode == mode {
+		return
+	}
+	editor.mode = mode
+	editor.pending = []rune{}
+	if !editor.replaying {
+		editor.announcer.Announce(mode.String())
+	}
+}
+
+// Handles a single key press, returning false if the key means nothing to the editor
+// (such as enter) and should be handled by the application instead
+func (editor *Editor) HandleKey(key rune) bool {
+	if key == '\r' || key == '\n' {
+		return false
+	}
+	if editor.mode == INSERT {
+		editor.handleInsertKey(key)
+	} else {
+		editor.handleNormalKey(key)
+	}
+	return true
+}
+
+func (editor *Editor) handleInsertKey(key rune) {
+	if editor.recordingInsert && !editor.replaying {
+		editor.lastChange = append(editor.lastChange, key)
+	}
+	switch key {
+	case ESCAPE:
+		editor.recordingInsert = false
+		value, position := editor.value()
+		if position > lineStart(value, position) {
+			editor.buffer.SetCursor(previousCharacter(value, position))
+		}
+		editor.SetMode(NORMAL)
+	case BACKSPACE, CONTROL_H:
+		editor.buffer.RemoveCharacter()
+	default:
+		editor.buffer.AddCharacter(key)
+	}
+}
+
+func (editor *Editor) handleNormalKey(key rune) {
+	if key == ESCAPE {
+		editor.pending = []rune{}
+		return
+	}
+	editor.pending = append(editor.pending, key)
+	cmd, status := parseCommand(editor.pending)
+	switch status {
+	case INCOMPLETE:
+		return
+	case INVALID:
+		editor.pending = []rune{}
+		return
+	}
+
+	keys := editor.pending
+	editor.pending = []rune{}
+	if cmd.action == '.' {
+		editor.repeatLastChange(cmd.count)
+		return
+	}
+	if cmd.isChange() && !editor.replaying {
+		editor.lastChange = keys
+		editor.recordingInsert = false
+	}
+	editor.run(cmd)
+	if editor.mode == INSERT && cmd.isChange() && !editor.replaying {
+		editor.recordingInsert = true
+	}
+}
+
+// Runs the last change again.  A count given to . replaces the change's own count,
+// or repeats the change that many times if it had none.
+func (editor *Editor) repeatLastChange(count int) {
+	if len(editor.lastChange) == 0 {
+		return
+	}
+	keys := editor.lastChange
+	if count == 0 {
+		count = 1
+	} else if counted, _ := parseCommand(keys); counted.count > 0 || counted.motionCount > 0 {
+		keys = withCount(keys, count)
+		count = 1
+	}
+	editor.replaying = true
+	for i := 0; i < count; i++ {
+		for _, key := range keys {
+			editor.HandleKey(key)
+		}
+	}
+	editor.replaying = false
+}
+
+func (editor *Editor) value() (string, int) {
+	return editor.buffer.OutputWithoutPrefix()
+}
+
+func (editor *Editor) setValue(value string, position int) {
+	editor.buffer.SetString(value).SetCursor(position)
+}
+
+// Keeps the cursor on a character in normal mode, rather than after the end of a line
+func (editor *Editor) settleCursor() {
+	value, position := editor.value()
+	end := lineEnd(value, position)
+	if position >= end && end > lineStart(value, position) {
+		editor.buffer.SetCursor(previousCharacter(value, end))
+	}
+}
+
+func (editor *Editor) run(cmd command) {
+	value, position := editor.value()
+	count := cmd.repetitions()
+
+	switch cmd.action {
+	case 'i':
+		editor.SetMode(INSERT)
+		return
+	case 'a':
+		if position < lineEnd(value, position) {
+			editor.buffer.SetCursor(nextCharacter(value, position))
+		}
+		editor.SetMode(INSERT)
+		return
+	case 'I':
+		editor.buffer.SetCursor(firstNonBlank(value, position))
+		editor.SetMode(INSERT)
+		return
+	case 'A':
+		editor.buffer.SetCursor(lineEnd(value, position))
+		editor.SetMode(INSERT)
+		return
+	case 'x':
+		cmd = command{operator: 'd', motion: 'l', count: count}
+	case 'X':
+		cmd = command{operator: 'd', motion: 'h', count: count}
+	case 's':
+		cmd = command{operator: 'c', motion: 'l', count: count}
+	case 'D':
+		cmd = command{operator: 'd', motion: '$'}
+	case 'C':
+		cmd = command{operator: 'c', motion: '$'}
+	case 'p', 'P':
+		editor.put(cmd.action == 'P', count)
+		return
+	}
+
+	if cmd.operator == 0 {
+		target, _, found := motionTarget(value, position, cmd.motion, cmd.argument, count)
+		if found {
+			editor.buffer.SetCursor(target)
+			editor.settleCursor()
+		}
+		return
+	}
+
+	if cmd.motion == cmd.operator {
+		editor.applyLinewise(cmd.operator, count)
+		return
+	}
+
+	motion := cmd.motion
+	// cw acts like ce when on a word, as it does in vi
+	if cmd.operator == 'c' && motion == 'w' &&
+		position < len(value) && classOf(value[position]) != whitespaceClass {
+		motion = 'e'
+	}
+	target, inclusive, found := motionTarget(value, position, motion, cmd.argument, count)
+	if !found {
+		return
+	}
+	start := utils.IntMin(position, target)
+	end := utils.IntMax(position, target)
+	if inclusive {
+		end = nextCharacter(value, end)
+	}
+	// Deleting words on the last word of a line does not join it to the next line
+	if motion == 'w' {
+		end = utils.IntMin(end, utils.IntMax(lineEnd(value, start), start))
+	}
+	editor.applyRange(cmd.operator, start, end)
+}
+
+func (editor *Editor) applyRange(operator rune, start int, end int) {
+	if start == end {
+		if operator == 'c' {
+			editor.SetMode(INSERT)
+		}
+		return
+	}
+	value, _ := editor.value()
+	editor.register = value[start:end]
+	editor.registerLinewise = false
+	switch operator {
+	case 'y':
+		editor.buffer.SetCursor(start)
+	case 'd':
+		editor.setValue(value[:start]+value[end:], start)
+		editor.settleCursor()
+	case 'c':
+		editor.setValue(value[:start]+value[end:], start)
+		editor.SetMode(INSERT)
+	}
+}
+
+func (editor *Editor) applyLinewise(operator rune, count int) {
+	value, position := editor.value()
+	start := lineStart(value, position)
+	end := lineEnd(value, position)
+	for i := 1; i < count && end < len(value); i++ {
+		end = lineEnd(value, end+1)
+	}
+	editor.register = value[start:end]
+	editor.registerLinewise = true
+
+	switch operator {
+	case 'y':
+		editor.buffer.SetCursor(utils.IntMax(position, start))
+	case 'c':
+		editor.setValue(value[:start]+value[end:], start)
+		editor.SetMode(INSERT)
+	case 'd':
+		if end < len(value) {
+			end += 1
+		} else if start > 0 {
+			start -= 1
+		}
+		value = value[:start] + value[end:]
+		editor.setValue(value, firstNonBlank(value, utils.IntMin(start, len(value))))
+		editor.settleCursor()
+	}
+}
+
+// Puts the register after the cursor, or before it if before is set
+func (editor *Editor) put(before bool, count int) {
+	if editor.register == "" {
+		return
+	}
+	value, position := editor.value()
+	text := strings.Repeat(editor.register, count)
+
+	if editor.registerLinewise {
+		// Each copy of the lines goes on lines of its own
+		text = strings.Repeat("\n"+editor.register, count)[1:]
+		if before {
+			at := lineStart(value, position)
+			editor.setValue(value[:at]+text+"\n"+value[at:], at)
+		} else {
+			at := lineEnd(value, position)
+			editor.setValue(value[:at]+"\n"+text+value[at:], at+1)
+		}
+		return
+	}
+
+	at := position
+	if !before && position < lineEnd(value, position) {
+		at = nextCharacter(value, at)
+	}
+	value = value[:at] + text + value[at:]
+	editor.setValue(value, previousCharacter(value, at+len(text)))
+}
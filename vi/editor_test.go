@@ -0,0 +1,286 @@
+package vi
+
+import (
+	"testing"
+
+	"github.com/bekreth/screen_reader_terminal/buffer"
+	"github.com/bekreth/screen_reader_terminal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const esc = string(ESCAPE)
+
+func TestHandleKey(t *testing.T) {
+	trials := []struct {
+		description      string
+		startingValue    string
+		startingPosition int
+		keys             string
+		expectedValue    string
+		expectedPosition int
+		expectedMode     Mode
+	}{
+		{
+			description:      "Move forward by words",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "2w",
+			expectedValue:    "one two three",
+			expectedPosition: 8,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Move to end of word and back",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "eeb",
+			expectedValue:    "one two three",
+			expectedPosition: 4,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Move to line start and end",
+			startingValue:    "one\ntwo three",
+			startingPosition: 6,
+			keys:             "$",
+			expectedValue:    "one\ntwo three",
+			expectedPosition: 12,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Find and till characters",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "ftTo",
+			expectedValue:    "one two three",
+			expectedPosition: 1,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete word with count",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "d2w",
+			expectedValue:    "three",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete last word does not join lines",
+			startingValue:    "one two\nthree",
+			startingPosition: 4,
+			keys:             "dw",
+			expectedValue:    "one \nthree",
+			expectedPosition: 3,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete to end of line",
+			startingValue:    "one two three",
+			startingPosition: 4,
+			keys:             "d$",
+			expectedValue:    "one ",
+			expectedPosition: 3,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete up to a character",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "dtr",
+			expectedValue:    "ree",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete line",
+			startingValue:    "one\ntwo\nthree",
+			startingPosition: 5,
+			keys:             "dd",
+			expectedValue:    "one\nthree",
+			expectedPosition: 4,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Change word enters insert mode",
+			startingValue:    "one two three",
+			startingPosition: 4,
+			keys:             "cwfour",
+			expectedValue:    "one four three",
+			expectedPosition: 8,
+			expectedMode:     INSERT,
+		},
+		{
+			description:      "Repeat change word",
+			startingValue:    "one two three",
+			startingPosition: 0,
+			keys:             "cwsix" + esc + "w.",
+			expectedValue:    "six six three",
+			expectedPosition: 6,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Repeat delete character with count",
+			startingValue:    "abcdef",
+			startingPosition: 0,
+			keys:             "x3.",
+			expectedValue:    "ef",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Count given to repeat replaces the original count",
+			startingValue:    "abcdefgh",
+			startingPosition: 0,
+			keys:             "3x2.",
+			expectedValue:    "fgh",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Repeat without a count keeps the original count",
+			startingValue:    "one two three four five",
+			startingPosition: 0,
+			keys:             "d2w.",
+			expectedValue:    "five",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Count given to repeat replaces both counts",
+			startingValue:    "a b c d e f g h",
+			startingPosition: 0,
+			keys:             "2d2w3.",
+			expectedValue:    "h",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Yank and put word",
+			startingValue:    "one two",
+			startingPosition: 0,
+			keys:             "yw$p",
+			expectedValue:    "one twoone ",
+			expectedPosition: 10,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Yank and put line",
+			startingValue:    "one\ntwo",
+			startingPosition: 0,
+			keys:             "yyP",
+			expectedValue:    "one\none\ntwo",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Put line with count puts each copy on its own line",
+			startingValue:    "foo\nbar",
+			startingPosition: 0,
+			keys:             "yy2p",
+			expectedValue:    "foo\nfoo\nfoo\nbar",
+			expectedPosition: 4,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Delete a multibyte character",
+			startingValue:    "éa",
+			startingPosition: 0,
+			keys:             "x",
+			expectedValue:    "a",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Move right over a multibyte character",
+			startingValue:    "aéb",
+			startingPosition: 0,
+			keys:             "2l",
+			expectedValue:    "aéb",
+			expectedPosition: 3,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Find and delete to a multibyte character",
+			startingValue:    "a café au lait",
+			startingPosition: 0,
+			keys:             "dfé",
+			expectedValue:    " au lait",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Find backwards to a multibyte character",
+			startingValue:    "été",
+			startingPosition: 3,
+			keys:             "Fé",
+			expectedValue:    "été",
+			expectedPosition: 0,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Change to the end of a multibyte word",
+			startingValue:    "café noir",
+			startingPosition: 0,
+			keys:             "cwéa" + esc,
+			expectedValue:    "éa noir",
+			expectedPosition: 2,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Append at end of line",
+			startingValue:    "one\ntwo",
+			startingPosition: 0,
+			keys:             "A!" + esc,
+			expectedValue:    "one!\ntwo",
+			expectedPosition: 3,
+			expectedMode:     NORMAL,
+		},
+		{
+			description:      "Invalid command is discarded",
+			startingValue:    "one two",
+			startingPosition: 0,
+			keys:             "dzw",
+			expectedValue:    "one two",
+			expectedPosition: 4,
+			expectedMode:     NORMAL,
+		},
+	}
+
+	for _, trial := range trials {
+		t.Run(trial.description, func(tt *testing.T) {
+			buf := buffer.NewBufferWithString(trial.startingValue)
+			buf.SetCursor(trial.startingPosition)
+			editor := NewEditor(&buf, utils.NoOpAnnouncer{})
+			editor.mode = NORMAL
+
+			for _, key := range trial.keys {
+				editor.HandleKey(key)
+			}
+
+			actualValue, actualPosition := buf.OutputWithoutPrefix()
+			assert.Equal(tt, trial.expectedValue, actualValue)
+			assert.Equal(tt, trial.expectedPosition, actualPosition)
+			assert.Equal(tt, trial.expectedMode, editor.Mode())
+		})
+	}
+}
+
+func TestModeAnnouncements(t *testing.T) {
+	buf := buffer.NewBuffer()
+	announcer := utils.TestAnnouncer{}
+	editor := NewEditor(&buf, &announcer)
+
+	for _, key := range "hi" + esc + "a!" + esc + "." {
+		editor.HandleKey(key)
+	}
+
+	assert.Equal(
+		t,
+		[]string{"normal mode", "insert mode", "normal mode"},
+		announcer.Messages,
+	)
+	value, _ := buf.OutputWithoutPrefix()
+	assert.Equal(t, "hi!!", value)
+	assert.False(t, editor.HandleKey('\r'))
+}
@@ -0,0 +1,197 @@
+package vi
+
+import "unicode/utf8"
+
+const (
+	whitespaceClass = 0
+	keywordClass    = 1
+	symbolClass     = 2
+)
+
+// Characters are grouped the same way vi splits words: runs of letters, digits and
+// underscores, runs of other symbols, and the white space between them
+func classOf(character byte) int {
+	switch {
+	case character == ' ' || character == '\t' || character == '\n':
+		return whitespaceClass
+	case character == '_' ||
+		(character >= 'a' && character <= 'z') ||
+		(character >= 'A' && character <= 'Z') ||
+		(character >= '0' && character <= '9') ||
+		character >= 0x80:
+		return keywordClass
+	default:
+		return symbolClass
+	}
+}
+
+// Index of the character after the one at position
+func nextCharacter(value string, position int) int {
+	if position >= len(value) {
+		return len(value)
+	}
+	_, size := utf8.DecodeRuneInString(value[position:])
+	return position + size
+}
+
+// Index of the character before position
+func previousCharacter(value string, position int) int {
+	if position <= 0 {
+		return 0
+	}
+	_, size := utf8.DecodeLastRuneInString(value[:position])
+	return position - size
+}
+
+func characterAt(value string, position int) rune {
+	character, _ := utf8.DecodeRuneInString(value[position:])
+	return character
+}
+
+func lineStart(value string, position int) int {
+	for position > 0 && value[position-1] != '\n' {
+		position -= 1
+	}
+	return position
+}
+
+func lineEnd(value string, position int) int {
+	for position < len(value) && value[position] != '\n' {
+		position += 1
+	}
+	return position
+}
+
+func firstNonBlank(value string, position int) int {
+	position = lineStart(value, position)
+	end := lineEnd(value, position)
+	for position < end && classOf(value[position]) == whitespaceClass {
+		position += 1
+	}
+	return position
+}
+
+func nextWordStart(value string, position int) int {
+	if position >= len(value) {
+		return len(value)
+	}
+	class := classOf(value[position])
+	for position < len(value) && class != whitespaceClass &&
+		classOf(value[position]) == class {
+		position += 1
+	}
+	for position < len(value) && classOf(value[position]) == whitespaceClass {
+		position += 1
+	}
+	return position
+}
+
+func nextWordEnd(value string, position int) int {
+	if value == "" {
+		return 0
+	}
+	position += 1
+	for position < len(value) && classOf(value[position]) == whitespaceClass {
+		position += 1
+	}
+	if position >= len(value) {
+		return previousCharacter(value, len(value))
+	}
+	class := classOf(value[position])
+	for position+1 < len(value) && classOf(value[position+1]) == class {
+		position += 1
+	}
+	// Land on the start of the last character rather than its last byte
+	for position > 0 && !utf8.RuneStart(value[position]) {
+		position -= 1
+	}
+	return position
+}
+
+func previousWordStart(value string, position int) int {
+	for position > 0 && classOf(value[position-1]) == whitespaceClass {
+		position -= 1
+	}
+	if position == 0 {
+		return 0
+	}
+	class := classOf(value[position-1])
+	for position > 0 && classOf(value[position-1]) == class {
+		position -= 1
+	}
+	return position
+}
+
+// Finds the position a motion moves the cursor to from position.  Inclusive motions
+// cover the character at the target when used with an operator.  If the motion
+// cannot be made, such as f to a character not on the line, found is false.
+func motionTarget(
+	value string,
+	position int,
+	motion rune,
+	argument rune,
+	count int,
+) (target int, inclusive bool, found bool) {
+	start := lineStart(value, position)
+	end := lineEnd(value, position)
+	target = position
+
+	switch motion {
+	case 'h':
+		for i := 0; i < count && target > start; i++ {
+			target = previousCharacter(value, target)
+		}
+	case 'l':
+		for i := 0; i < count && target < end; i++ {
+			target = nextCharacter(value, target)
+		}
+	case '0':
+		target = start
+	case '$':
+		target = end
+	case 'w':
+		for i := 0; i < count; i++ {
+			target = nextWordStart(value, target)
+		}
+	case 'e':
+		for i := 0; i < count; i++ {
+			target = nextWordEnd(value, target)
+		}
+		inclusive = true
+	case 'b':
+		for i := 0; i < count; i++ {
+			target = previousWordStart(value, target)
+		}
+	case 'f', 't':
+		for i := 0; i < count; i++ {
+			target = nextCharacter(value, target)
+			for target < end && characterAt(value, target) != argument {
+				target = nextCharacter(value, target)
+			}
+			if target >= end {
+				return position, false, false
+			}
+		}
+		if motion == 't' {
+			target = previousCharacter(value, target)
+		}
+		inclusive = true
+	case 'F', 'T':
+		for i := 0; i < count; i++ {
+			if target <= start {
+				return position, false, false
+			}
+			target = previousCharacter(value, target)
+			for target > start && characterAt(value, target) != argument {
+				target = previousCharacter(value, target)
+			}
+			if characterAt(value, target) != argument {
+				return position, false, false
+			}
+		}
+		if motion == 'T' {
+			target = nextCharacter(value, target)
+		}
+	}
+	return target, inclusive, true
+}
@@ -20,6 +20,20 @@ type Window interface {
 	// for negative
 	ScrollPage(int)
 
+	// Shell integration marks, OSC 133, letting terminals find each prompt, the input
+	// typed at it and the output of the command that followed
+	MarkPromptStart()
+	MarkInputStart()
+	MarkOutputStart()
+	MarkCommandFinished(exitCode int)
+
+	// Rings the terminal bell
+	Bell()
+
+	// Asks the terminal to wrap pasted text in markers so it can be told apart from typing
+	EnableBracketedPaste()
+	DisableBracketedPaste()
+
 	Write([]byte) (int, error)
 }
 
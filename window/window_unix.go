@@ -8,7 +8,11 @@ import (
 	tsize "github.com/kopoli/go-terminal-size"
 )
 
-const CSI = "\x1B["
+const (
+	CSI = "\x1B["
+	OSC = "\x1B]"
+	ST  = "\x1B\\"
+)
 
 type unixWindow struct {
 	size WindowSize
@@ -89,3 +93,31 @@ func (window unixWindow) ScrollPage(input int) {
 		window.file.Write([]byte(fmt.Sprintf("%v%v%v", CSI, -1*input, "T")))
 	}
 }
+
+func (window unixWindow) MarkPromptStart() {
+	window.file.Write([]byte(fmt.Sprintf("%v133;A%v", OSC, ST)))
+}
+
+func (window unixWindow) MarkInputStart() {
+	window.file.Write([]byte(fmt.Sprintf("%v133;B%v", OSC, ST)))
+}
+
+func (window unixWindow) MarkOutputStart() {
+	window.file.Write([]byte(fmt.Sprintf("%v133;C%v", OSC, ST)))
+}
+
+func (window unixWindow) MarkCommandFinished(exitCode int) {
+	window.file.Write([]byte(fmt.Sprintf("%v133;D;%v%v", OSC, exitCode, ST)))
+}
+
+func (window unixWindow) Bell() {
+	window.file.Write([]byte("\a"))
+}
+
+func (window unixWindow) EnableBracketedPaste() {
+	window.file.Write([]byte(fmt.Sprintf("%v?2004h", CSI)))
+}
+
+func (window unixWindow) DisableBracketedPaste() {
+	window.file.Write([]byte(fmt.Sprintf("%v?2004l", CSI)))
+}